@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	configv1alpha1 "github.com/gardener/aws-custom-route-controller/pkg/apis/config/v1alpha1"
+)
+
+var cleanupRetries int
+
+var cleanupCommand = &command{
+	description: "deletes all routes managed by the controller from the route tables of the cluster",
+	addFlags: func(fs *pflag.FlagSet) {
+		fs.IntVar(&cleanupRetries, "retries", 5, "number of retries after the first attempt to delete all routes")
+	},
+	validators: []func(*configv1alpha1.ControllerConfiguration) field.ErrorList{
+		configv1alpha1.ValidateAWSConfiguration,
+		validateCleanupRetries,
+	},
+	run: runCleanup,
+}
+
+func validateCleanupRetries(_ *configv1alpha1.ControllerConfiguration) field.ErrorList {
+	if cleanupRetries < 0 {
+		return field.ErrorList{field.Invalid(field.NewPath("retries"), cleanupRetries, "must not be negative")}
+	}
+	return nil
+}
+
+func runCleanup(ctx context.Context, cfg *configv1alpha1.ControllerConfiguration, log logr.Logger) error {
+	customRoutes, _, err := newCustomRoutes(cfg, log)
+	if err != nil {
+		return err
+	}
+	result, err := customRoutes.Cleanup(ctx, wait.Backoff{
+		// the first attempt is a step, too
+		Steps:    cleanupRetries + 1,
+		Duration: 2 * time.Second,
+		Factor:   2,
+		Jitter:   0.1,
+	})
+	printSyncSummary(os.Stdout, result, nil)
+	return err
+}
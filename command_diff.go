@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	configv1alpha1 "github.com/gardener/aws-custom-route-controller/pkg/apis/config/v1alpha1"
+	"github.com/gardener/aws-custom-route-controller/pkg/updater"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+var diffOutput string
+
+var diffCommand = &command{
+	description: "prints the desired vs. actual routes per route table without changing anything",
+	addFlags: func(fs *pflag.FlagSet) {
+		fs.StringVarP(&diffOutput, "output", "o", outputTable, "output format. Must be one of [table,json,yaml].")
+	},
+	validators: []func(*configv1alpha1.ControllerConfiguration) field.ErrorList{
+		configv1alpha1.ValidateAWSConfiguration,
+		configv1alpha1.ValidateTargetConfiguration,
+	},
+	run: runDiff,
+}
+
+// diffReport is the output of the diff and plan commands
+type diffReport struct {
+	Tables    []updater.RouteTableDiff `json:"tables"`
+	Conflicts []updater.RouteConflict  `json:"conflicts,omitempty"`
+}
+
+func runDiff(ctx context.Context, cfg *configv1alpha1.ControllerConfiguration, log logr.Logger) error {
+	if err := checkOutputFormat(diffOutput); err != nil {
+		return err
+	}
+
+	customRoutes, podCIDRs, err := newCustomRoutes(cfg, log)
+	if err != nil {
+		return err
+	}
+	nodes, err := listNodes(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	routes, conflicts, err := updater.NodeRoutesFromNodes(nodes, podCIDRs)
+	if err != nil {
+		return err
+	}
+	diffs, err := customRoutes.Diff(ctx, routes)
+	if err != nil {
+		return err
+	}
+	return printDiffReport(os.Stdout, diffOutput, &diffReport{Tables: diffs, Conflicts: conflicts})
+}
+
+func checkOutputFormat(format string) error {
+	switch format {
+	case outputTable, outputJSON, outputYAML:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %q", format)
+	}
+}
+
+func printDiffReport(out io.Writer, format string, report *diffReport) error {
+	switch format {
+	case outputJSON:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case outputYAML:
+		data, err := yaml.Marshal(report)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLE\tVPC\tACTION\tDESTINATION\tTARGET")
+	for _, table := range report.Tables {
+		for _, route := range table.ToBeCreated {
+			fmt.Fprintf(w, "%s\t%s\tcreate\t%s\t%s\n", table.TableID, table.VPCID, route.PodCIDR, diffTarget(table, route))
+		}
+		for _, route := range table.ToBeDeleted {
+			fmt.Fprintf(w, "%s\t%s\tdelete\t%s\t%s\n", table.TableID, table.VPCID, route.PodCIDR, route.InstanceID)
+		}
+		for _, route := range table.Unchanged {
+			fmt.Fprintf(w, "%s\t%s\tkeep\t%s\t%s\n", table.TableID, table.VPCID, route.PodCIDR, diffTarget(table, route))
+		}
+		for _, route := range table.UnmanagedRoutes {
+			fmt.Fprintf(w, "%s\t%s\tunmanaged\t%s\t%s\n", table.TableID, table.VPCID, route.Destination, route.Target)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	for _, conflict := range report.Conflicts {
+		fmt.Fprintf(out, "excluded node %s: %s (%s)\n", conflict.NodeName, conflict.Message, conflict.Reason)
+	}
+	return nil
+}
+
+// diffTarget returns the target of a desired route, which is the VPC peering connection for nodes in peered VPCs
+func diffTarget(table updater.RouteTableDiff, route updater.NodeRoute) string {
+	if route.InstanceID == "" {
+		return table.VPCPeeringConnectionIDs[route.PodCIDR]
+	}
+	return route.InstanceID
+}
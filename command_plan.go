@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	configv1alpha1 "github.com/gardener/aws-custom-route-controller/pkg/apis/config/v1alpha1"
+	"github.com/gardener/aws-custom-route-controller/pkg/updater"
+)
+
+var (
+	planNodesFile       string
+	planRouteTablesFile string
+	planOutput          string
+)
+
+var planCommand = &command{
+	description: "calculates the route changes offline from a node list and a route table snapshot",
+	addFlags: func(fs *pflag.FlagSet) {
+		fs.StringVar(&planNodesFile, "nodes", "", "path of node list as YAML or JSON, e.g. from 'kubectl get nodes -o yaml'")
+		fs.StringVar(&planRouteTablesFile, "route-tables", "", "path of route tables as JSON, e.g. from 'aws ec2 describe-route-tables'")
+		fs.StringVarP(&planOutput, "output", "o", outputTable, "output format. Must be one of [table,json,yaml].")
+	},
+	run: runPlan,
+}
+
+func runPlan(ctx context.Context, cfg *configv1alpha1.ControllerConfiguration, log logr.Logger) error {
+	if err := checkOutputFormat(planOutput); err != nil {
+		return err
+	}
+	if planNodesFile == "" || planRouteTablesFile == "" {
+		return fmt.Errorf("'--nodes' and '--route-tables' are required")
+	}
+	if cfg.MultiVPC {
+		// the VPCs of the nodes and the VPC peering connections are not part of the route table snapshot
+		return fmt.Errorf("'--multi-vpc' is not supported by 'plan', use 'diff' instead")
+	}
+
+	nodes, err := readNodes(planNodesFile)
+	if err != nil {
+		return err
+	}
+	routeTablesData, err := os.ReadFile(planRouteTablesFile)
+	if err != nil {
+		return err
+	}
+	ec2Routes, err := updater.NewSnapshotEC2Routes(routeTablesData)
+	if err != nil {
+		return err
+	}
+	customRoutes, podCIDRs, err := newCustomRoutesFor(cfg, log, ec2Routes)
+	if err != nil {
+		return err
+	}
+
+	routes, conflicts, err := updater.NodeRoutesFromNodes(nodes, podCIDRs)
+	if err != nil {
+		return err
+	}
+	diffs, err := customRoutes.Diff(ctx, routes)
+	if err != nil {
+		return err
+	}
+	return printDiffReport(os.Stdout, planOutput, &diffReport{Tables: diffs, Conflicts: conflicts})
+}
+
+// readNodes reads a node list or a single node from a YAML or JSON file
+func readNodes(path string) ([]corev1.Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	nodeList := &corev1.NodeList{}
+	if err := yaml.Unmarshal(data, nodeList); err != nil {
+		return nil, fmt.Errorf("could not parse nodes: %w", err)
+	}
+	if nodeList.Kind == "Node" {
+		node := corev1.Node{}
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return nil, fmt.Errorf("could not parse node: %w", err)
+		}
+		return []corev1.Node{node}, nil
+	}
+	return nodeList.Items, nil
+}
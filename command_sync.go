@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	configv1alpha1 "github.com/gardener/aws-custom-route-controller/pkg/apis/config/v1alpha1"
+	"github.com/gardener/aws-custom-route-controller/pkg/updater"
+)
+
+var syncOnce bool
+
+var syncCommand = &command{
+	description: "runs the route update once and prints a summary per route table",
+	addFlags: func(fs *pflag.FlagSet) {
+		fs.BoolVar(&syncOnce, "once", false, "run a single sync and exit (required)")
+	},
+	validators: []func(*configv1alpha1.ControllerConfiguration) field.ErrorList{
+		configv1alpha1.ValidateAWSConfiguration,
+		configv1alpha1.ValidateTargetConfiguration,
+	},
+	run: runSync,
+}
+
+func runSync(ctx context.Context, cfg *configv1alpha1.ControllerConfiguration, log logr.Logger) error {
+	if !syncOnce {
+		return fmt.Errorf("'--once' is required, use the controller mode for continuous syncing")
+	}
+
+	customRoutes, podCIDRs, err := newCustomRoutes(cfg, log)
+	if err != nil {
+		return err
+	}
+	nodes, err := listNodes(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	routes, conflicts, err := updater.NodeRoutesFromNodes(nodes, podCIDRs)
+	if err != nil {
+		return err
+	}
+	if len(routes) == 0 {
+		// same safeguard as in the controller to avoid cleaning the route tables
+		return fmt.Errorf("no valid node routes found for %d nodes, refusing to sync", len(nodes))
+	}
+
+	result, err := customRoutes.Update(ctx, routes, func() {})
+	printSyncSummary(os.Stdout, result, conflicts)
+	if err != nil {
+		return err
+	}
+	if failed := countFailedRoutes(result) + len(conflicts); failed > 0 {
+		return fmt.Errorf("%d node routes could not be created", failed)
+	}
+	return nil
+}
+
+func printSyncSummary(out io.Writer, result *updater.RouteUpdateResult, conflicts []updater.RouteConflict) {
+	if result == nil {
+		return
+	}
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLE\tVPC\tDESIRED\tACTUAL\tCREATED\tDELETED\tFAILED\tUNMANAGED")
+	for _, table := range slices.Concat(result.Tables, result.TransitGatewayTables) {
+		var created, deleted, failed int
+		for _, outcome := range result.Outcomes {
+			if outcome.TableID != table.TableID {
+				continue
+			}
+			switch {
+			case outcome.Err != nil:
+				failed++
+			case outcome.Operation == updater.RouteOperationCreate, outcome.Operation == updater.RouteOperationReplace:
+				created++
+			case outcome.Operation == updater.RouteOperationDelete:
+				deleted++
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\n", table.TableID, table.VPCID, table.DesiredRoutes, table.ActualRoutes,
+			created, deleted, failed, len(table.UnmanagedRoutes))
+	}
+	_ = w.Flush()
+
+	for _, conflict := range conflicts {
+		fmt.Fprintf(out, "excluded node %s: %s (%s)\n", conflict.NodeName, conflict.Message, conflict.Reason)
+	}
+	for _, podCIDR := range slices.Sorted(maps.Keys(result.Failures)) {
+		failure := result.Failures[podCIDR]
+		fmt.Fprintf(out, "skipped route %s: %s (%s)\n", podCIDR, failure.Message, failure.Reason)
+	}
+}
+
+func countFailedRoutes(result *updater.RouteUpdateResult) int {
+	failed := 0
+	for _, ok := range result.SuccessfulRoutes {
+		if !ok {
+			failed++
+		}
+	}
+	return failed
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+
+	configv1alpha1 "github.com/gardener/aws-custom-route-controller/pkg/apis/config/v1alpha1"
+)
+
+// command is a subcommand running a single task instead of the long-running controller
+type command struct {
+	// description is a short description shown in the usage
+	description string
+	// addFlags adds the command specific flags
+	addFlags func(fs *pflag.FlagSet)
+	// validators validate the configuration parts needed by the command in addition to the common ones
+	validators []func(*configv1alpha1.ControllerConfiguration) field.ErrorList
+	// run runs the command. A returned error results in a non-zero exit code.
+	run func(ctx context.Context, cfg *configv1alpha1.ControllerConfiguration, log logr.Logger) error
+}
+
+// commands are all subcommands by name
+var commands = map[string]*command{
+	"cleanup": cleanupCommand,
+	"diff":    diffCommand,
+	"plan":    planCommand,
+	"sync":    syncCommand,
+}
+
+func runCommand(name string, cmd *command, args []string) {
+	fs := pflag.NewFlagSet(name, pflag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s %s: %s\n", os.Args[0], name, cmd.description)
+		fs.PrintDefaults()
+	}
+	if cmd.addFlags != nil {
+		cmd.addFlags(fs)
+	}
+	fs.AddFlagSet(pflag.CommandLine)
+	_ = fs.Parse(args)
+
+	cfg, log := setupConfigurationAndLogger(cmd.validators...)
+	if err := cmd.run(signals.SetupSignalHandler(), cfg, log); err != nil {
+		log.Error(err, "command failed", "command", name)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	pflag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
+		pflag.PrintDefaults()
+		var names []string
+		for name := range commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(os.Stderr, "\nCommands (run '%s <command> --help' for details):\n", os.Args[0])
+		for _, name := range names {
+			fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].description)
+		}
+	}
+}
+
+// listNodes lists all nodes of the target cluster
+func listNodes(ctx context.Context, cfg *configv1alpha1.ControllerConfiguration) ([]corev1.Node, error) {
+	targetConfig, err := clientcmd.BuildConfigFromFlags("", cfg.TargetKubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not use target kubeconfig %s: %w", cfg.TargetKubeconfig, err)
+	}
+	c, err := client.New(targetConfig, client.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("could not create target client: %w", err)
+	}
+	nodeList := &corev1.NodeList{}
+	if err := c.List(ctx, nodeList); err != nil {
+		return nil, fmt.Errorf("listing nodes failed: %w", err)
+	}
+	return nodeList.Items, nil
+}
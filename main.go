@@ -9,19 +9,28 @@ import (
 	"strings"
 	"time"
 
+	configv1alpha1 "github.com/gardener/aws-custom-route-controller/pkg/apis/config/v1alpha1"
+	"github.com/gardener/aws-custom-route-controller/pkg/apis/routes/v1alpha1"
 	"github.com/gardener/aws-custom-route-controller/pkg/controller"
+	"github.com/gardener/aws-custom-route-controller/pkg/status"
 	"github.com/gardener/aws-custom-route-controller/pkg/updater"
 	"github.com/gardener/aws-custom-route-controller/pkg/util"
 	"github.com/gardener/aws-custom-route-controller/pkg/util/logger"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/pflag"
+	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -38,54 +47,73 @@ const (
 )
 
 var (
+	awsCABundle             = pflag.String("aws-ca-bundle", "", "path of a PEM file with additional CA certificates for the AWS endpoints")
 	clusterName             = pflag.String("cluster-name", "", "cluster name used for AWS tags")
+	configFile              = pflag.String("config", "", "path of the configuration file. Flags override values of the file.")
 	controlKubeconfig       = pflag.String("control-kubeconfig", updater.InClusterConfig, fmt.Sprintf("path of control plane kubeconfig or '%s' for in-cluster config", updater.InClusterConfig))
+	credentialsDirectory    = pflag.String("credentials-directory", "", "directory containing a file per credentials secret data key for the directory credentials source")
+	credentialsSource       = pflag.String("credentials-source", updater.CredentialsSourceControlSecret, fmt.Sprintf("source of the AWS credentials. Must be one of [%s].", strings.Join(updater.AllCredentialsSources, ",")))
+	ec2Endpoint             = pflag.String("ec2-endpoint", "", "URL overriding the AWS EC2 endpoint, e.g. for LocalStack")
 	healthProbePort         = pflag.Int("health-probe-port", 8081, "port for health probes")
 	maxDelay                = pflag.Duration("max-delay-on-failure", 5*time.Minute, "maximum delay if communication with AWS fails")
 	metricsPort             = pflag.Int("metrics-port", 8080, "port for metrics")
-	namespace               = pflag.String("namespace", "", "namespace of secret containing the AWS credentials on control plane")
-	podNetworkCidr          = pflag.String("pod-network-cidr", "", "CIDR for pod network")
+	multiVPC                = pflag.Bool("multi-vpc", false, "manage route tables across peered VPCs: node routes only in tables of the node's VPC, routes to the VPC peering connection with the node's VPC in tables of other VPCs")
+	namespace               = pflag.String("namespace", "", "namespace of secret containing the AWS credentials on control plane or in target cluster")
+	podNetworkCidr          = pflag.String("pod-network-cidr", "", "comma separated list of CIDRs for pod network")
+	prefixListContent       = pflag.String("prefix-list-content", updater.PrefixListContentNodePodCIDRs, fmt.Sprintf("content of the managed prefix list. Must be one of [%s].", strings.Join(updater.AllPrefixListContents, ",")))
+	prefixListID            = pflag.String("prefix-list-id", "", "ID of a managed prefix list owned by the controller, which is kept in sync with the node pod CIDRs or the pod networks")
+	protectedCidrs          = pflag.String("protected-cidrs", "", "comma separated list of destination CIDRs for which routes are never created or deleted")
+	quarantineAfter         = pflag.Int("quarantine-after-failures", 5, "number of consecutive failures after which a node route is only retried once per sync period, 0 disables the quarantine")
+	readySyncPeriods        = pflag.Int("ready-sync-periods", 3, "number of sync periods within which the last route update must have succeeded for readiness")
 	region                  = pflag.String("region", "", "AWS region")
-	secretName              = pflag.String("secret-name", "cloudprovider", "name of secret containing the AWS credentials on control plane")
+	routeLimit              = pflag.Int("route-limit", updater.DefaultRouteLimit, "maximum number of routes per route table, i.e. the applied value of the Amazon VPC quota 'Routes per route table'")
+	routeLimitWarning       = pflag.Int("route-limit-warning-headroom", 5, "number of routes left in a route table at which a warning event is emitted, 0 warns only about full route tables")
+	routeTableStatus        = pflag.Bool("route-table-status", false, "report route table status as RouteTableStatus objects in the target cluster (requires the CRD)")
+	secretName              = pflag.String("secret-name", "cloudprovider", "name of secret containing the AWS credentials on control plane or in target cluster")
+	securityGroupRules      = pflag.Bool("security-group-rules", false, fmt.Sprintf("ensure ingress rules for the pod networks in the cluster security groups tagged with %s", updater.SecurityGroupTagKey))
+	stsEndpoint             = pflag.String("sts-endpoint", "", "URL overriding the AWS STS endpoint, also used for web identity")
 	syncPeriod              = pflag.Duration("sync-period", 1*time.Hour, "period for syncing routes")
 	targetKubeconfig        = pflag.String("target-kubeconfig", "", "path of target kubeconfig")
+	tgwAttachmentID         = pflag.String("transit-gateway-attachment-id", "", "ID of the transit gateway attachment of the cluster VPC, the target of the transit gateway routes")
+	tgwRouteTableIDs        = pflag.String("transit-gateway-route-table-ids", "", "comma separated list of transit gateway route table IDs which get a static route per pod network CIDR")
 	tickPeriod              = pflag.Duration("tick-period", 5*time.Second, "tick period for checking for updates")
+	timeToRouteAnnotation   = pflag.Bool("time-to-route-annotation", false, fmt.Sprintf("annotate new nodes with the time until their routes were confirmed in all route tables (%s)", updater.TimeToRouteAnnotation))
+	useDualStackEndpoint    = pflag.Bool("use-dualstack-endpoint", false, "use the AWS dual-stack endpoints")
+	useFIPSEndpoint         = pflag.Bool("use-fips-endpoint", false, "use the AWS FIPS endpoints")
 	leaderElection          = pflag.Bool("leader-election", false, "enable leader election")
 	leaderElectionNamespace = pflag.String("leader-election-namespace", "kube-system", "namespace for the lease resource")
 	logLevel                = pflag.String("log-level", logger.InfoLevel, "LogLevel is the level/severity for the logs. Must be one of [info,debug,error].")
 	logFormat               = pflag.String("log-format", logger.FormatJSON, "output format for the logs. Must be one of [text,json].")
+
+	// atomicLogLevel is the level of the logger, which can be changed at runtime
+	atomicLogLevel = zap.NewAtomicLevel()
 )
 
 func main() {
-
-	logf.SetLogger(logger.MustNewZapLogger(*logLevel, *logFormat))
-
-	var log = logf.Log.WithName(componentName)
-	klog.SetLogger(log)
-	log.Info("version", "version", Version)
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			runCommand(os.Args[1], command, os.Args[2:])
+			return
+		}
+	}
 
 	pflag.Parse()
-	checkRequiredFlag(log, "namespace", *namespace)
-	checkRequiredFlag(log, "secret-name", *secretName)
-	checkRequiredFlag(log, "region", *region)
-	checkRequiredFlag(log, "cluster-name", *clusterName)
-	checkRequiredFlag(log, "pod-network-cidr", *podNetworkCidr)
-	checkRequiredFlag(log, "target-kubeconfig", *targetKubeconfig)
+	cfg, log := setupConfigurationAndLogger(configv1alpha1.ValidateAWSConfiguration, configv1alpha1.ValidateTargetConfiguration)
 
-	targetConfig, err := clientcmd.BuildConfigFromFlags("", *targetKubeconfig)
+	targetConfig, err := clientcmd.BuildConfigFromFlags("", cfg.TargetKubeconfig)
 	if err != nil {
-		log.Error(err, "could not use target kubeconfig", "target-kubeconfig", *targetKubeconfig)
+		log.Error(err, "could not use target kubeconfig", "target-kubeconfig", cfg.TargetKubeconfig)
 		os.Exit(1)
 	}
 	options := manager.Options{
-		LeaderElection:             *leaderElection,
+		LeaderElection:             cfg.LeaderElection.Enabled,
 		LeaderElectionResourceLock: resourcelock.LeasesResourceLock,
 		LeaderElectionID:           leaderElectionId,
-		LeaderElectionNamespace:    *leaderElectionNamespace,
+		LeaderElectionNamespace:    cfg.LeaderElection.Namespace,
 		Metrics: server.Options{
-			BindAddress: fmt.Sprintf(":%d", *metricsPort),
+			BindAddress: fmt.Sprintf(":%d", cfg.Server.MetricsPort),
 		},
-		HealthProbeBindAddress: fmt.Sprintf(":%d", *healthProbePort),
+		HealthProbeBindAddress: fmt.Sprintf(":%d", cfg.Server.HealthProbePort),
 	}
 	mgr, err := manager.New(targetConfig, options)
 	if err != nil {
@@ -112,41 +140,304 @@ func main() {
 		log.Error(err, "could not add healthz checker")
 		os.Exit(1)
 	}
-
-	credentials, err := updater.LoadCredentials(*controlKubeconfig, *namespace, *secretName)
+	err = mgr.AddMetricsServerExtraHandler("/status", reconciler.StatusHandler())
 	if err != nil {
-		log.Error(err, "could not load AWS credentials", "namespace", *namespace, "secretName", *secretName)
+		log.Error(err, "could not add status handler")
 		os.Exit(1)
 	}
-	ec2Routes, err := updater.NewAWSEC2Routes(credentials, *region)
+	reconciler.SetReadySyncPeriods(cfg.Sync.ReadySyncPeriods)
+	reconciler.SetRouteLimitWarningHeadroom(ptr.Deref(cfg.RouteLimit.WarningHeadroom, 0))
+	reconciler.SetTimeToRouteAnnotation(cfg.TimeToRouteAnnotation)
+
+	customRoutes, podCIDRs, err := newCustomRoutes(cfg, log)
 	if err != nil {
-		log.Error(err, "could not create AWS EC2 interface")
+		log.Error(err, "could not create AWS custom routes updater")
 		os.Exit(1)
 	}
-	podCIDR, err := util.GetIPv4CIDR(strings.Split(*podNetworkCidr, ","))
-	if err != nil {
-		log.Error(err, "could not parse IPv4 address from pod-network-cidr")
+	if err := reconciler.SetPodNetworks(podCIDRs); err != nil {
+		log.Error(err, "could not set pod networks")
 		os.Exit(1)
 	}
 
-	customRoutes, err := updater.NewCustomRoutes(log.WithName("updater"), ec2Routes, *clusterName, podCIDR)
-	if err != nil {
-		log.Error(err, "could not create AWS custom routes updater")
+	preflight := controller.NewPreflight(log, mgr.GetEventRecorder(componentName), customRoutes.Preflight)
+	if err := mgr.Add(preflight); err != nil {
+		log.Error(err, "could not add preflight checks")
 		os.Exit(1)
 	}
+	if err := mgr.AddReadyzCheck("preflight", preflight.ReadyChecker); err != nil {
+		log.Error(err, "could not add preflight ready checker")
+		os.Exit(1)
+	}
+
+	if cfg.RouteTableStatus {
+		scheme := runtime.NewScheme()
+		if err := v1alpha1.AddToScheme(scheme); err != nil {
+			log.Error(err, "could not create scheme")
+			os.Exit(1)
+		}
+		statusClient, err := client.New(targetConfig, client.Options{Scheme: scheme})
+		if err != nil {
+			log.Error(err, "could not create route table status client")
+			os.Exit(1)
+		}
+		customRoutes.SetStatusReporter(status.NewRouteTableStatusReporter(statusClient))
+	}
 
 	ctx := signals.SetupSignalHandler()
-	reconciler.StartUpdater(ctx, customRoutes.Update, *tickPeriod, *syncPeriod, *maxDelay)
+	reconciler.StartUpdater(ctx, customRoutes.Update, cfg.Sync.TickPeriod.Duration, cfg.Sync.SyncPeriod.Duration, cfg.Sync.MaxDelayOnFailure.Duration)
+	if *configFile != "" {
+		reloader := &configReloader{
+			log:          log.WithName("config"),
+			current:      cfg,
+			reconciler:   reconciler,
+			customRoutes: customRoutes,
+		}
+		if err := reloader.start(ctx, *configFile); err != nil {
+			log.Error(err, "could not watch configuration file", "config", *configFile)
+			os.Exit(1)
+		}
+	}
+	if cfg.Credentials.Source == updater.CredentialsSourceDirectory {
+		credentialsLog := log.WithName("credentials")
+		err := watchDirectory(ctx, credentialsLog, cfg.Credentials.Directory, func() {
+			reloadCredentials(cfg, credentialsLog, customRoutes)
+		})
+		if err != nil {
+			log.Error(err, "could not watch credentials directory", "directory", cfg.Credentials.Directory)
+			os.Exit(1)
+		}
+	}
 	if err := mgr.Start(ctx); err != nil {
 		log.Error(err, "could not start manager")
 		os.Exit(1)
 	}
 }
 
-func checkRequiredFlag(log logr.Logger, name, value string) {
-	if value == "" {
-		log.Info(fmt.Sprintf("'--%s' is required", name))
-		pflag.Usage()
+// setupConfigurationAndLogger loads and validates the configuration and sets up the logger.
+// All validation errors are logged at once before exiting.
+func setupConfigurationAndLogger(validators ...func(*configv1alpha1.ControllerConfiguration) field.ErrorList) (*configv1alpha1.ControllerConfiguration, logr.Logger) {
+	cfg, err := loadConfiguration()
+	if err == nil {
+		allErrs := configv1alpha1.ValidateControllerConfiguration(cfg)
+		for _, validate := range validators {
+			allErrs = append(allErrs, validate(cfg)...)
+		}
+		err = allErrs.ToAggregate()
+	}
+
+	level, format := logger.InfoLevel, logger.FormatJSON
+	if err == nil {
+		level, format = cfg.Log.Level, cfg.Log.Format
+	}
+	zapLevel, _ := logger.ParseLevel(level)
+	atomicLogLevel.SetLevel(zapLevel)
+	logf.SetLogger(logger.MustNewZapLogger(level, format, logzap.Level(atomicLogLevel)))
+
+	var log = logf.Log.WithName(componentName)
+	klog.SetLogger(log)
+	log.Info("version", "version", Version)
+
+	if err != nil {
+		log.Error(err, "invalid configuration")
 		os.Exit(1)
 	}
+	return cfg, log
+}
+
+// loadConfiguration loads the configuration file if specified and overrides it with the flags set on the command line
+func loadConfiguration() (*configv1alpha1.ControllerConfiguration, error) {
+	cfg := configv1alpha1.NewDefaultControllerConfiguration()
+	if *configFile != "" {
+		var err error
+		cfg, err = configv1alpha1.LoadControllerConfiguration(*configFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load configuration file %s: %w", *configFile, err)
+		}
+	}
+
+	pflag.CommandLine.VisitAll(func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		switch f.Name {
+		case "aws-ca-bundle":
+			cfg.Endpoints.CABundle = *awsCABundle
+		case "cluster-name":
+			cfg.ClusterName = *clusterName
+		case "credentials-directory":
+			cfg.Credentials.Directory = *credentialsDirectory
+		case "credentials-source":
+			cfg.Credentials.Source = *credentialsSource
+		case "control-kubeconfig":
+			cfg.Credentials.ControlKubeconfig = *controlKubeconfig
+		case "ec2-endpoint":
+			cfg.Endpoints.EC2 = *ec2Endpoint
+		case "health-probe-port":
+			cfg.Server.HealthProbePort = *healthProbePort
+		case "max-delay-on-failure":
+			cfg.Sync.MaxDelayOnFailure.Duration = *maxDelay
+		case "multi-vpc":
+			cfg.MultiVPC = *multiVPC
+		case "metrics-port":
+			cfg.Server.MetricsPort = *metricsPort
+		case "namespace":
+			cfg.Credentials.Namespace = *namespace
+		case "pod-network-cidr":
+			cfg.PodNetworkCIDRs = splitList(*podNetworkCidr)
+		case "ready-sync-periods":
+			cfg.Sync.ReadySyncPeriods = *readySyncPeriods
+		case "prefix-list-id":
+			cfg.PrefixList.ID = *prefixListID
+		case "prefix-list-content":
+			cfg.PrefixList.Content = *prefixListContent
+		case "quarantine-after-failures":
+			cfg.Sync.QuarantineAfterFailures = ptr.To(*quarantineAfter)
+		case "protected-cidrs":
+			cfg.ProtectedCIDRs = splitList(*protectedCidrs)
+		case "region":
+			cfg.Region = *region
+		case "route-limit":
+			cfg.RouteLimit.Limit = *routeLimit
+		case "route-limit-warning-headroom":
+			cfg.RouteLimit.WarningHeadroom = ptr.To(*routeLimitWarning)
+		case "sts-endpoint":
+			cfg.Endpoints.STS = *stsEndpoint
+		case "use-dualstack-endpoint":
+			cfg.Endpoints.UseDualStack = *useDualStackEndpoint
+		case "use-fips-endpoint":
+			cfg.Endpoints.UseFIPS = *useFIPSEndpoint
+		case "security-group-rules":
+			cfg.SecurityGroupRules = *securityGroupRules
+		case "secret-name":
+			cfg.Credentials.SecretName = *secretName
+		case "sync-period":
+			cfg.Sync.SyncPeriod.Duration = *syncPeriod
+		case "target-kubeconfig":
+			cfg.TargetKubeconfig = *targetKubeconfig
+		case "transit-gateway-attachment-id":
+			cfg.TransitGateway.AttachmentID = *tgwAttachmentID
+		case "transit-gateway-route-table-ids":
+			cfg.TransitGateway.RouteTableIDs = splitList(*tgwRouteTableIDs)
+		case "tick-period":
+			cfg.Sync.TickPeriod.Duration = *tickPeriod
+		case "time-to-route-annotation":
+			cfg.TimeToRouteAnnotation = *timeToRouteAnnotation
+		case "leader-election":
+			cfg.LeaderElection.Enabled = *leaderElection
+		case "leader-election-namespace":
+			cfg.LeaderElection.Namespace = *leaderElectionNamespace
+		case "log-level":
+			cfg.Log.Level = *logLevel
+		case "route-table-status":
+			cfg.RouteTableStatus = *routeTableStatus
+		case "log-format":
+			cfg.Log.Format = *logFormat
+		}
+	})
+	return cfg, nil
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// newCustomRoutes creates the AWS custom routes updater from the configuration.
+// It returns the updater and the IPv4 CIDRs of the pod network.
+func newCustomRoutes(cfg *configv1alpha1.ControllerConfiguration, log logr.Logger) (*updater.CustomRoutes, []string, error) {
+	ec2Routes, callerIdentity, err := newAWSClients(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	customRoutes, podCIDRs, err := newCustomRoutesFor(cfg, log, ec2Routes)
+	if err != nil {
+		return nil, nil, err
+	}
+	customRoutes.SetCallerIdentity(callerIdentity)
+	return customRoutes, podCIDRs, nil
+}
+
+// newAWSClients loads the credentials from the configured source and creates the AWS clients
+func newAWSClients(cfg *configv1alpha1.ControllerConfiguration) (updater.EC2Routes, updater.CallerIdentity, error) {
+	credentials, err := loadCredentials(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	clientOptions := awsClientOptions(cfg)
+	ec2Routes, err := updater.NewAWSEC2Routes(credentials, clientOptions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create AWS EC2 interface: %w", err)
+	}
+	callerIdentity, err := updater.NewAWSCallerIdentity(credentials, clientOptions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create AWS STS interface: %w", err)
+	}
+	return ec2Routes, callerIdentity, nil
+}
+
+// loadCredentials loads the AWS credentials from the configured source
+func loadCredentials(cfg *configv1alpha1.ControllerConfiguration) (*updater.Credentials, error) {
+	var (
+		credentials *updater.Credentials
+		err         error
+	)
+	switch cfg.Credentials.Source {
+	case updater.CredentialsSourceTargetSecret:
+		credentials, err = updater.LoadCredentials(cfg.TargetKubeconfig, cfg.Credentials.Namespace, cfg.Credentials.SecretName)
+	case updater.CredentialsSourceDirectory:
+		credentials, err = updater.LoadCredentialsFromDirectory(cfg.Credentials.Directory)
+		if err != nil {
+			return nil, fmt.Errorf("could not load AWS credentials from directory %s: %w", cfg.Credentials.Directory, err)
+		}
+	case updater.CredentialsSourceDefaultChain:
+		credentials = updater.DefaultChainCredentials()
+	default:
+		credentials, err = updater.LoadCredentials(cfg.Credentials.ControlKubeconfig, cfg.Credentials.Namespace, cfg.Credentials.SecretName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not load AWS credentials from secret %s/%s: %w", cfg.Credentials.Namespace, cfg.Credentials.SecretName, err)
+	}
+	return credentials, nil
+}
+
+// awsClientOptions returns the options of the AWS clients from the configuration
+func awsClientOptions(cfg *configv1alpha1.ControllerConfiguration) updater.AWSClientOptions {
+	return updater.AWSClientOptions{
+		Region:               cfg.Region,
+		EC2Endpoint:          cfg.Endpoints.EC2,
+		STSEndpoint:          cfg.Endpoints.STS,
+		UseFIPSEndpoint:      cfg.Endpoints.UseFIPS,
+		UseDualStackEndpoint: cfg.Endpoints.UseDualStack,
+		CABundle:             cfg.Endpoints.CABundle,
+	}
+}
+
+// setRouteBackoff configures the backoff of failing node routes from the sync configuration
+func setRouteBackoff(customRoutes *updater.CustomRoutes, sync configv1alpha1.SyncConfiguration) {
+	customRoutes.SetRouteBackoff(sync.TickPeriod.Duration, sync.MaxDelayOnFailure.Duration, ptr.Deref(sync.QuarantineAfterFailures, 0), sync.SyncPeriod.Duration)
+}
+
+// newCustomRoutesFor creates the AWS custom routes updater from the configuration for the given EC2 interface.
+func newCustomRoutesFor(cfg *configv1alpha1.ControllerConfiguration, log logr.Logger, ec2Routes updater.EC2Routes) (*updater.CustomRoutes, []string, error) {
+	podCIDRs, err := util.GetIPv4CIDRs(cfg.PodNetworkCIDRs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse IPv4 address from pod network CIDRs: %w", err)
+	}
+
+	customRoutes, err := updater.NewCustomRoutes(log.WithName("updater"), ec2Routes, cfg.ClusterName, podCIDRs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := customRoutes.SetProtectedCIDRs(cfg.ProtectedCIDRs); err != nil {
+		return nil, nil, fmt.Errorf("could not parse protected CIDRs: %w", err)
+	}
+	customRoutes.SetMultiVPC(cfg.MultiVPC)
+	customRoutes.SetTransitGateway(cfg.TransitGateway.RouteTableIDs, cfg.TransitGateway.AttachmentID)
+	customRoutes.SetPrefixList(cfg.PrefixList.ID, cfg.PrefixList.Content)
+	customRoutes.SetSecurityGroupRules(cfg.SecurityGroupRules)
+	customRoutes.SetRouteQuota(updater.StaticRouteQuota(cfg.RouteLimit.Limit))
+	setRouteBackoff(customRoutes, cfg.Sync)
+	return customRoutes, podCIDRs, nil
 }
@@ -0,0 +1,68 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package v1alpha1
+
+import (
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/gardener/aws-custom-route-controller/pkg/updater"
+	"github.com/gardener/aws-custom-route-controller/pkg/util/logger"
+)
+
+// SetDefaults_ControllerConfiguration sets the defaults for all unset fields.
+func SetDefaults_ControllerConfiguration(obj *ControllerConfiguration) {
+	if obj.Credentials.Source == "" {
+		obj.Credentials.Source = updater.CredentialsSourceControlSecret
+	}
+	if obj.Credentials.ControlKubeconfig == "" {
+		obj.Credentials.ControlKubeconfig = updater.InClusterConfig
+	}
+	if obj.Credentials.SecretName == "" {
+		obj.Credentials.SecretName = "cloudprovider"
+	}
+	if obj.PrefixList.Content == "" {
+		obj.PrefixList.Content = updater.PrefixListContentNodePodCIDRs
+	}
+	if obj.RouteLimit.Limit == 0 {
+		obj.RouteLimit.Limit = updater.DefaultRouteLimit
+	}
+	if obj.RouteLimit.WarningHeadroom == nil {
+		obj.RouteLimit.WarningHeadroom = ptr.To(5)
+	}
+	if obj.Sync.SyncPeriod.Duration == 0 {
+		obj.Sync.SyncPeriod.Duration = 1 * time.Hour
+	}
+	if obj.Sync.TickPeriod.Duration == 0 {
+		obj.Sync.TickPeriod.Duration = 5 * time.Second
+	}
+	if obj.Sync.MaxDelayOnFailure.Duration == 0 {
+		obj.Sync.MaxDelayOnFailure.Duration = 5 * time.Minute
+	}
+	if obj.Sync.ReadySyncPeriods == 0 {
+		obj.Sync.ReadySyncPeriods = 3
+	}
+	if obj.Sync.QuarantineAfterFailures == nil {
+		obj.Sync.QuarantineAfterFailures = ptr.To(5)
+	}
+	if obj.Server.HealthProbePort == 0 {
+		obj.Server.HealthProbePort = 8081
+	}
+	if obj.Server.MetricsPort == 0 {
+		obj.Server.MetricsPort = 8080
+	}
+	if obj.LeaderElection.Namespace == "" {
+		obj.LeaderElection.Namespace = "kube-system"
+	}
+	if obj.Log.Level == "" {
+		obj.Log.Level = logger.InfoLevel
+	}
+	if obj.Log.Format == "" {
+		obj.Log.Format = logger.FormatJSON
+	}
+}
@@ -0,0 +1,8 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package v1alpha1 contains the versioned configuration file of the aws-custom-route-controller.
+package v1alpha1
@@ -0,0 +1,48 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package v1alpha1
+
+import (
+	"fmt"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// NewDefaultControllerConfiguration returns a configuration with all defaults set.
+func NewDefaultControllerConfiguration() *ControllerConfiguration {
+	obj := &ControllerConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: APIVersion,
+			Kind:       Kind,
+		},
+	}
+	SetDefaults_ControllerConfiguration(obj)
+	return obj
+}
+
+// LoadControllerConfiguration reads the configuration file and sets the defaults.
+// Unknown fields are rejected.
+func LoadControllerConfiguration(path string) (*ControllerConfiguration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeControllerConfiguration(data)
+}
+
+// DecodeControllerConfiguration decodes the configuration from YAML or JSON and sets the defaults.
+// Unknown fields are rejected.
+func DecodeControllerConfiguration(data []byte) (*ControllerConfiguration, error) {
+	obj := &ControllerConfiguration{}
+	if err := yaml.UnmarshalStrict(data, obj); err != nil {
+		return nil, fmt.Errorf("could not decode configuration: %w", err)
+	}
+	SetDefaults_ControllerConfiguration(obj)
+	return obj, nil
+}
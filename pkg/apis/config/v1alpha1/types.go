@@ -0,0 +1,202 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// APIVersion is the API version of the configuration file.
+	APIVersion = "config.aws.gardener.cloud/v1alpha1"
+	// Kind is the kind of the configuration file.
+	Kind = "ControllerConfiguration"
+)
+
+// ControllerConfiguration is the configuration of the aws-custom-route-controller.
+type ControllerConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// ClusterName is the cluster name used for AWS tags.
+	ClusterName string `json:"clusterName"`
+	// Region is the AWS region.
+	Region string `json:"region"`
+	// Endpoints configures the AWS endpoints, e.g. for LocalStack, FIPS or isolated partitions.
+	// +optional
+	Endpoints EndpointsConfiguration `json:"endpoints"`
+	// PodNetworkCIDRs are the CIDRs of the pod network. All IPv4 CIDRs are managed.
+	PodNetworkCIDRs []string `json:"podNetworkCIDRs"`
+	// ProtectedCIDRs are destination CIDRs for which routes are never created or deleted.
+	// +optional
+	ProtectedCIDRs []string `json:"protectedCIDRs,omitempty"`
+	// TargetKubeconfig is the path of the target kubeconfig.
+	TargetKubeconfig string `json:"targetKubeconfig"`
+	// MultiVPC enables managing route tables across several peered VPCs. Node routes are only created in
+	// route tables of the node's VPC. Route tables of other VPCs get a route per node for its pod CIDR to the
+	// VPC peering connection with the node's VPC, as the nodes of a pod network may be spread over several VPCs.
+	// +optional
+	MultiVPC bool `json:"multiVPC,omitempty"`
+	// TransitGateway configures static routes for the pod networks in transit gateway route tables.
+	// +optional
+	TransitGateway TransitGatewayConfiguration `json:"transitGateway"`
+	// PrefixList configures a managed prefix list kept in sync with the node pod CIDRs or the pod networks.
+	// +optional
+	PrefixList PrefixListConfiguration `json:"prefixList"`
+	// SecurityGroupRules enables ingress rules for the pod networks in the security groups tagged with the cluster tag
+	// and the tag key 'aws-custom-route-controller.gardener.cloud/pod-network-ingress'.
+	// +optional
+	SecurityGroupRules bool `json:"securityGroupRules,omitempty"`
+	// RouteLimit configures the maximum number of routes per route table and the warning before it is reached.
+	// +optional
+	RouteLimit RouteLimitConfiguration `json:"routeLimit"`
+	// Credentials configures the source of the AWS credentials.
+	Credentials CredentialsConfiguration `json:"credentials"`
+	// Sync configures the timing of the route updates.
+	// +optional
+	Sync SyncConfiguration `json:"sync"`
+	// Server configures the ports of the health probes and metrics.
+	// +optional
+	Server ServerConfiguration `json:"server"`
+	// LeaderElection configures the leader election.
+	// +optional
+	LeaderElection LeaderElectionConfiguration `json:"leaderElection"`
+	// Log configures the logging.
+	// +optional
+	Log LogConfiguration `json:"log"`
+	// RouteTableStatus enables reporting the route table status as RouteTableStatus objects in the target cluster.
+	// +optional
+	RouteTableStatus bool `json:"routeTableStatus,omitempty"`
+	// TimeToRouteAnnotation enables writing the time from the first appearance of a node pod CIDR until its routes
+	// were confirmed in all route tables as annotation 'aws-custom-route-controller.gardener.cloud/time-to-route'
+	// on the node.
+	// +optional
+	TimeToRouteAnnotation bool `json:"timeToRouteAnnotation,omitempty"`
+}
+
+// EndpointsConfiguration configures the AWS endpoints.
+type EndpointsConfiguration struct {
+	// EC2 overrides the URL of the EC2 endpoint.
+	// +optional
+	EC2 string `json:"ec2,omitempty"`
+	// STS overrides the URL of the STS endpoint. It is also used for web identity.
+	// +optional
+	STS string `json:"sts,omitempty"`
+	// UseFIPS selects the FIPS endpoints.
+	// +optional
+	UseFIPS bool `json:"useFIPS,omitempty"`
+	// UseDualStack selects the dual-stack endpoints.
+	// +optional
+	UseDualStack bool `json:"useDualStack,omitempty"`
+	// CABundle is the path of a PEM file with additional CA certificates for the AWS endpoints.
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+}
+
+// TransitGatewayConfiguration configures static routes for the pod networks in transit gateway route tables.
+type TransitGatewayConfiguration struct {
+	// RouteTableIDs are the IDs of the transit gateway route tables which get a static route per pod network CIDR.
+	// +optional
+	RouteTableIDs []string `json:"routeTableIDs,omitempty"`
+	// AttachmentID is the ID of the transit gateway attachment of the cluster VPC, which is the target of the routes.
+	// It is required if route tables are configured.
+	// +optional
+	AttachmentID string `json:"attachmentID,omitempty"`
+}
+
+// PrefixListConfiguration configures a managed prefix list kept in sync with the node pod CIDRs or the pod networks.
+type PrefixListConfiguration struct {
+	// ID is the ID of the managed prefix list. The prefix list is owned by the controller, i.e. all other entries are removed.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Content is the content of the prefix list. Must be one of [node-pod-cidrs,pod-networks].
+	// +optional
+	Content string `json:"content,omitempty"`
+}
+
+// RouteLimitConfiguration configures the maximum number of routes per route table.
+type RouteLimitConfiguration struct {
+	// Limit is the maximum number of routes per route table, i.e. the applied value of the Amazon VPC quota
+	// 'Routes per route table'. Defaults to 50.
+	// +optional
+	Limit int `json:"limit"`
+	// WarningHeadroom is the number of routes left in a route table at which a warning event is emitted. Zero warns
+	// only about full route tables. Defaults to 5.
+	// +optional
+	WarningHeadroom *int `json:"warningHeadroom,omitempty"`
+}
+
+// CredentialsConfiguration configures the source of the AWS credentials.
+type CredentialsConfiguration struct {
+	// Source is the source of the credentials. Must be one of [control-secret,target-secret,directory,default-chain].
+	// +optional
+	Source string `json:"source"`
+	// ControlKubeconfig is the path of the control plane kubeconfig or 'inClusterConfig' for in-cluster config.
+	// +optional
+	ControlKubeconfig string `json:"controlKubeconfig"`
+	// Namespace is the namespace of the secret on the control plane or in the target cluster.
+	// It is required for the secret sources.
+	// +optional
+	Namespace string `json:"namespace"`
+	// SecretName is the name of the secret on the control plane or in the target cluster.
+	// +optional
+	SecretName string `json:"secretName"`
+	// Directory is the directory containing a file per secret data key for the directory source.
+	// +optional
+	Directory string `json:"directory,omitempty"`
+}
+
+// SyncConfiguration configures the timing of the route updates.
+type SyncConfiguration struct {
+	// SyncPeriod is the period for syncing routes.
+	// +optional
+	SyncPeriod metav1.Duration `json:"syncPeriod"`
+	// TickPeriod is the tick period for checking for updates.
+	// +optional
+	TickPeriod metav1.Duration `json:"tickPeriod"`
+	// MaxDelayOnFailure is the maximum delay if communication with AWS fails.
+	// +optional
+	MaxDelayOnFailure metav1.Duration `json:"maxDelayOnFailure"`
+	// ReadySyncPeriods is the number of sync periods within which the last route update must have succeeded
+	// for the controller to be ready.
+	// +optional
+	ReadySyncPeriods int `json:"readySyncPeriods"`
+	// QuarantineAfterFailures is the number of consecutive failures after which a node route is quarantined,
+	// i.e. only retried once per sync period. Failing node routes are retried with their own backoff before.
+	// Zero disables the quarantine. Defaults to 5.
+	// +optional
+	QuarantineAfterFailures *int `json:"quarantineAfterFailures,omitempty"`
+}
+
+// ServerConfiguration configures the ports of the health probes and metrics.
+type ServerConfiguration struct {
+	// HealthProbePort is the port for health probes.
+	// +optional
+	HealthProbePort int `json:"healthProbePort"`
+	// MetricsPort is the port for metrics.
+	// +optional
+	MetricsPort int `json:"metricsPort"`
+}
+
+// LeaderElectionConfiguration configures the leader election.
+type LeaderElectionConfiguration struct {
+	// Enabled enables leader election.
+	// +optional
+	Enabled bool `json:"enabled"`
+	// Namespace is the namespace for the lease resource.
+	// +optional
+	Namespace string `json:"namespace"`
+}
+
+// LogConfiguration configures the logging.
+type LogConfiguration struct {
+	// Level is the level/severity for the logs. Must be one of [info,debug,error].
+	// +optional
+	Level string `json:"level"`
+	// Format is the output format for the logs. Must be one of [text,json].
+	// +optional
+	Format string `json:"format"`
+}
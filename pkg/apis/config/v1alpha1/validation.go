@@ -0,0 +1,216 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/gardener/aws-custom-route-controller/pkg/updater"
+	"github.com/gardener/aws-custom-route-controller/pkg/util/logger"
+)
+
+// ValidateControllerConfiguration validates the fields needed in all modes.
+func ValidateControllerConfiguration(obj *ControllerConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if obj.APIVersion != APIVersion {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("apiVersion"), obj.APIVersion, []string{APIVersion}))
+	}
+	if obj.Kind != Kind {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("kind"), obj.Kind, []string{Kind}))
+	}
+	if obj.ClusterName == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("clusterName"), "cluster name is used for AWS tags (flag --cluster-name)"))
+	}
+
+	podNetworksPath := field.NewPath("podNetworkCIDRs")
+	hasIPv4 := false
+	for i, cidr := range obj.PodNetworkCIDRs {
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(podNetworksPath.Index(i), cidr, "must be a valid CIDR"))
+			continue
+		}
+		if ipnet.IP.To4() != nil {
+			hasIPv4 = true
+		}
+	}
+	if len(obj.PodNetworkCIDRs) == 0 {
+		allErrs = append(allErrs, field.Required(podNetworksPath, "at least one pod network CIDR is required (flag --pod-network-cidr)"))
+	} else if !hasIPv4 {
+		allErrs = append(allErrs, field.Invalid(podNetworksPath, obj.PodNetworkCIDRs, "at least one IPv4 CIDR is required"))
+	}
+	for i, cidr := range obj.ProtectedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("protectedCIDRs").Index(i), cidr, "must be a valid CIDR"))
+		}
+	}
+
+	transitGatewayPath := field.NewPath("transitGateway")
+	for i, id := range obj.TransitGateway.RouteTableIDs {
+		if !strings.HasPrefix(id, "tgw-rtb-") {
+			allErrs = append(allErrs, field.Invalid(transitGatewayPath.Child("routeTableIDs").Index(i), id, "must be a transit gateway route table ID"))
+		}
+	}
+	if len(obj.TransitGateway.RouteTableIDs) > 0 && obj.TransitGateway.AttachmentID == "" {
+		allErrs = append(allErrs, field.Required(transitGatewayPath.Child("attachmentID"), "transit gateway attachment of the cluster VPC is required for transit gateway route tables (flag --transit-gateway-attachment-id)"))
+	}
+	if obj.TransitGateway.AttachmentID != "" && !strings.HasPrefix(obj.TransitGateway.AttachmentID, "tgw-attach-") {
+		allErrs = append(allErrs, field.Invalid(transitGatewayPath.Child("attachmentID"), obj.TransitGateway.AttachmentID, "must be a transit gateway attachment ID"))
+	}
+
+	prefixListPath := field.NewPath("prefixList")
+	if obj.PrefixList.ID != "" && !strings.HasPrefix(obj.PrefixList.ID, "pl-") {
+		allErrs = append(allErrs, field.Invalid(prefixListPath.Child("id"), obj.PrefixList.ID, "must be a managed prefix list ID"))
+	}
+	if !slices.Contains(updater.AllPrefixListContents, obj.PrefixList.Content) {
+		allErrs = append(allErrs, field.NotSupported(prefixListPath.Child("content"), obj.PrefixList.Content, updater.AllPrefixListContents))
+	}
+
+	routeLimitPath := field.NewPath("routeLimit")
+	if obj.RouteLimit.Limit < 1 || obj.RouteLimit.Limit > updater.MaxRouteLimit {
+		allErrs = append(allErrs, field.Invalid(routeLimitPath.Child("limit"), obj.RouteLimit.Limit, fmt.Sprintf("must be between 1 and %d", updater.MaxRouteLimit)))
+	}
+	if headroom := obj.RouteLimit.WarningHeadroom; headroom != nil && (*headroom < 0 || *headroom >= obj.RouteLimit.Limit) {
+		allErrs = append(allErrs, field.Invalid(routeLimitPath.Child("warningHeadroom"), *headroom, "must not be negative and less than the limit"))
+	}
+
+	syncPath := field.NewPath("sync")
+	if obj.Sync.SyncPeriod.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(syncPath.Child("syncPeriod"), obj.Sync.SyncPeriod.String(), "must be positive"))
+	}
+	if obj.Sync.TickPeriod.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(syncPath.Child("tickPeriod"), obj.Sync.TickPeriod.String(), "must be positive"))
+	}
+	if obj.Sync.MaxDelayOnFailure.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(syncPath.Child("maxDelayOnFailure"), obj.Sync.MaxDelayOnFailure.String(), "must be positive"))
+	}
+	if obj.Sync.ReadySyncPeriods < 1 {
+		allErrs = append(allErrs, field.Invalid(syncPath.Child("readySyncPeriods"), obj.Sync.ReadySyncPeriods, "must be at least 1"))
+	}
+	if quarantineAfter := obj.Sync.QuarantineAfterFailures; quarantineAfter != nil && *quarantineAfter < 0 {
+		allErrs = append(allErrs, field.Invalid(syncPath.Child("quarantineAfterFailures"), *quarantineAfter, "must not be negative"))
+	}
+
+	serverPath := field.NewPath("server")
+	allErrs = append(allErrs, validatePort(serverPath.Child("healthProbePort"), obj.Server.HealthProbePort)...)
+	allErrs = append(allErrs, validatePort(serverPath.Child("metricsPort"), obj.Server.MetricsPort)...)
+
+	logPath := field.NewPath("log")
+	if !slices.Contains(logger.AllLogLevels, obj.Log.Level) {
+		allErrs = append(allErrs, field.NotSupported(logPath.Child("level"), obj.Log.Level, logger.AllLogLevels))
+	}
+	if !slices.Contains(logger.AllLogFormats, obj.Log.Format) {
+		allErrs = append(allErrs, field.NotSupported(logPath.Child("format"), obj.Log.Format, logger.AllLogFormats))
+	}
+
+	return allErrs
+}
+
+// ValidateAWSConfiguration validates the fields needed for accessing AWS.
+func ValidateAWSConfiguration(obj *ControllerConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if obj.Region == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("region"), "AWS region is required (flag --region)"))
+	}
+	endpointsPath := field.NewPath("endpoints")
+	allErrs = append(allErrs, validateEndpointURL(endpointsPath.Child("ec2"), obj.Endpoints.EC2)...)
+	allErrs = append(allErrs, validateEndpointURL(endpointsPath.Child("sts"), obj.Endpoints.STS)...)
+	credentialsPath := field.NewPath("credentials")
+	switch obj.Credentials.Source {
+	case updater.CredentialsSourceControlSecret, updater.CredentialsSourceTargetSecret:
+		if obj.Credentials.Namespace == "" {
+			allErrs = append(allErrs, field.Required(credentialsPath.Child("namespace"), "namespace of the credentials secret is required (flag --namespace)"))
+		}
+		if obj.Credentials.SecretName == "" {
+			allErrs = append(allErrs, field.Required(credentialsPath.Child("secretName"), "name of the credentials secret is required (flag --secret-name)"))
+		}
+		if obj.Credentials.Source == updater.CredentialsSourceTargetSecret && obj.TargetKubeconfig == "" {
+			allErrs = append(allErrs, field.Required(field.NewPath("targetKubeconfig"), "path of target kubeconfig is required for the target-secret credentials source (flag --target-kubeconfig)"))
+		}
+	case updater.CredentialsSourceDirectory:
+		if obj.Credentials.Directory == "" {
+			allErrs = append(allErrs, field.Required(credentialsPath.Child("directory"), "credentials directory is required (flag --credentials-directory)"))
+		}
+	case updater.CredentialsSourceDefaultChain:
+	default:
+		allErrs = append(allErrs, field.NotSupported(credentialsPath.Child("source"), obj.Credentials.Source, updater.AllCredentialsSources))
+	}
+
+	return allErrs
+}
+
+// ValidateTargetConfiguration validates the fields needed for accessing the target cluster.
+func ValidateTargetConfiguration(obj *ControllerConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if obj.TargetKubeconfig == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("targetKubeconfig"), "path of target kubeconfig is required (flag --target-kubeconfig)"))
+	}
+	if obj.LeaderElection.Enabled && obj.LeaderElection.Namespace == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("leaderElection", "namespace"), "namespace for the lease is required"))
+	}
+
+	return allErrs
+}
+
+// ValidateControllerConfigurationUpdate validates a configuration reloaded at runtime against the running one.
+// Only the sync timings, the pod network and protected CIDRs and the log level can be changed at runtime.
+func ValidateControllerConfigurationUpdate(newObj, oldObj *ControllerConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, f := range []struct {
+		path     *field.Path
+		new, old any
+	}{
+		{field.NewPath("clusterName"), newObj.ClusterName, oldObj.ClusterName},
+		{field.NewPath("region"), newObj.Region, oldObj.Region},
+		{field.NewPath("endpoints"), newObj.Endpoints, oldObj.Endpoints},
+		{field.NewPath("targetKubeconfig"), newObj.TargetKubeconfig, oldObj.TargetKubeconfig},
+		{field.NewPath("credentials"), newObj.Credentials, oldObj.Credentials},
+		{field.NewPath("server"), newObj.Server, oldObj.Server},
+		{field.NewPath("leaderElection"), newObj.LeaderElection, oldObj.LeaderElection},
+		{field.NewPath("log", "format"), newObj.Log.Format, oldObj.Log.Format},
+		{field.NewPath("multiVPC"), newObj.MultiVPC, oldObj.MultiVPC},
+		{field.NewPath("transitGateway"), newObj.TransitGateway, oldObj.TransitGateway},
+		{field.NewPath("prefixList"), newObj.PrefixList, oldObj.PrefixList},
+		{field.NewPath("securityGroupRules"), newObj.SecurityGroupRules, oldObj.SecurityGroupRules},
+		{field.NewPath("routeTableStatus"), newObj.RouteTableStatus, oldObj.RouteTableStatus},
+	} {
+		if !apiequality.Semantic.DeepEqual(f.new, f.old) {
+			allErrs = append(allErrs, field.Forbidden(f.path, "cannot be changed at runtime, a restart is required"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateEndpointURL(fldPath *field.Path, endpoint string) field.ErrorList {
+	if endpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return field.ErrorList{field.Invalid(fldPath, endpoint, "must be an http or https URL")}
+	}
+	return nil
+}
+
+func validatePort(fldPath *field.Path, port int) field.ErrorList {
+	if port < 0 || port > 65535 {
+		return field.ErrorList{field.Invalid(fldPath, port, "must be a valid port number")}
+	}
+	return nil
+}
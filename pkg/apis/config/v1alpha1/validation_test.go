@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("ControllerConfiguration", func() {
+	Describe("#DecodeControllerConfiguration", func() {
+		It("should decode the configuration and set the defaults", func() {
+			cfg, err := DecodeControllerConfiguration([]byte(`
+apiVersion: config.aws.gardener.cloud/v1alpha1
+kind: ControllerConfiguration
+clusterName: shoot--foo--bar
+region: eu-west-1
+podNetworkCIDRs: [10.243.0.0/16]
+credentials:
+  namespace: shoot--foo--bar
+sync:
+  syncPeriod: 30m
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.ClusterName).To(Equal("shoot--foo--bar"))
+			Expect(cfg.PodNetworkCIDRs).To(Equal([]string{"10.243.0.0/16"}))
+			Expect(cfg.Credentials.SecretName).To(Equal("cloudprovider"))
+			Expect(cfg.Sync.SyncPeriod.Duration).To(Equal(30 * time.Minute))
+			Expect(cfg.Sync.TickPeriod.Duration).To(Equal(5 * time.Second))
+			Expect(cfg.Server.MetricsPort).To(Equal(8080))
+			Expect(cfg.RouteLimit).To(Equal(RouteLimitConfiguration{Limit: 50, WarningHeadroom: ptr.To(5)}))
+			Expect(cfg.Sync.QuarantineAfterFailures).To(Equal(ptr.To(5)))
+			Expect(ValidateControllerConfiguration(cfg)).To(BeEmpty())
+			Expect(ValidateAWSConfiguration(cfg)).To(BeEmpty())
+		})
+
+		It("should keep zero values of fields with non-zero defaults", func() {
+			cfg, err := DecodeControllerConfiguration([]byte(`
+apiVersion: config.aws.gardener.cloud/v1alpha1
+kind: ControllerConfiguration
+clusterName: shoot--foo--bar
+podNetworkCIDRs: [10.243.0.0/16]
+routeLimit:
+  warningHeadroom: 0
+sync:
+  quarantineAfterFailures: 0
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.RouteLimit.WarningHeadroom).To(Equal(ptr.To(0)))
+			Expect(cfg.Sync.QuarantineAfterFailures).To(Equal(ptr.To(0)))
+			Expect(ValidateControllerConfiguration(cfg)).To(BeEmpty())
+		})
+
+		It("should reject unknown fields", func() {
+			_, err := DecodeControllerConfiguration([]byte(`
+apiVersion: config.aws.gardener.cloud/v1alpha1
+kind: ControllerConfiguration
+clusterName: shoot--foo--bar
+podNetworkCIDR: 10.243.0.0/16
+`))
+			Expect(err).To(MatchError(ContainSubstring(`unknown field "podNetworkCIDR"`)))
+		})
+	})
+
+	Describe("#ValidateControllerConfiguration", func() {
+		It("should accept the default configuration with required fields", func() {
+			cfg := NewDefaultControllerConfiguration()
+			cfg.ClusterName = "shoot--foo--bar"
+			cfg.PodNetworkCIDRs = []string{"10.243.0.0/16"}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(BeEmpty())
+		})
+
+		It("should report all errors at once", func() {
+			cfg := NewDefaultControllerConfiguration()
+			cfg.Kind = "Foo"
+			cfg.PodNetworkCIDRs = []string{"10.243.0.0/33", "fd00::/64"}
+			cfg.ProtectedCIDRs = []string{"foo"}
+			cfg.Sync.TickPeriod.Duration = -time.Second
+			cfg.Server.MetricsPort = 70000
+			cfg.Log.Level = "trace"
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeNotSupported), "Field": Equal("kind")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeRequired), "Field": Equal("clusterName")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("podNetworkCIDRs[0]")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("podNetworkCIDRs")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("protectedCIDRs[0]")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("sync.tickPeriod")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("server.metricsPort")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeNotSupported), "Field": Equal("log.level")})),
+			))
+		})
+
+		It("should require the attachment for transit gateway route tables", func() {
+			cfg := NewDefaultControllerConfiguration()
+			cfg.ClusterName = "shoot--foo--bar"
+			cfg.PodNetworkCIDRs = []string{"10.243.0.0/16"}
+			cfg.TransitGateway.RouteTableIDs = []string{"tgw-rtb-0123456789abcdef0", "rtb-0123456789abcdef0"}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("transitGateway.routeTableIDs[1]")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeRequired), "Field": Equal("transitGateway.attachmentID")})),
+			))
+		})
+
+		It("should validate the route limit", func() {
+			cfg := NewDefaultControllerConfiguration()
+			cfg.ClusterName = "shoot--foo--bar"
+			cfg.PodNetworkCIDRs = []string{"10.243.0.0/16"}
+			cfg.RouteLimit.Limit = 1001
+			cfg.RouteLimit.WarningHeadroom = ptr.To(-1)
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("routeLimit.limit")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("routeLimit.warningHeadroom")})),
+			))
+		})
+	})
+
+	Describe("#ValidateAWSConfiguration", func() {
+		It("should require region and namespace", func() {
+			cfg := NewDefaultControllerConfiguration()
+
+			Expect(ValidateAWSConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeRequired), "Field": Equal("region")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeRequired), "Field": Equal("credentials.namespace")})),
+			))
+		})
+
+		It("should validate the endpoint URLs", func() {
+			cfg := NewDefaultControllerConfiguration()
+			cfg.Region = "us-east-1"
+			cfg.Credentials.Namespace = "default"
+			cfg.Endpoints.EC2 = "http://localhost:4566"
+			cfg.Endpoints.STS = "localhost:4566"
+
+			Expect(ValidateAWSConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("endpoints.sts")})),
+			))
+		})
+	})
+
+	Describe("#ValidateControllerConfigurationUpdate", func() {
+		var oldCfg *ControllerConfiguration
+
+		BeforeEach(func() {
+			oldCfg = NewDefaultControllerConfiguration()
+			oldCfg.ClusterName = "shoot--foo--bar"
+			oldCfg.PodNetworkCIDRs = []string{"10.243.0.0/16"}
+		})
+
+		It("should allow changing the runtime settings", func() {
+			newCfg := *oldCfg
+			newCfg.PodNetworkCIDRs = []string{"10.243.0.0/16", "10.250.0.0/16"}
+			newCfg.ProtectedCIDRs = []string{"10.243.255.0/24"}
+			newCfg.Sync.SyncPeriod.Duration = 10 * time.Minute
+			newCfg.RouteLimit.Limit = 200
+			newCfg.TimeToRouteAnnotation = true
+			newCfg.Log.Level = "debug"
+
+			Expect(ValidateControllerConfigurationUpdate(&newCfg, oldCfg)).To(BeEmpty())
+		})
+
+		It("should reject changing other settings", func() {
+			newCfg := *oldCfg
+			newCfg.ClusterName = "other"
+			newCfg.Server.MetricsPort = 9090
+			newCfg.Log.Format = "text"
+
+			Expect(ValidateControllerConfigurationUpdate(&newCfg, oldCfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeForbidden), "Field": Equal("clusterName")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeForbidden), "Field": Equal("server")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeForbidden), "Field": Equal("log.format")})),
+			))
+		})
+	})
+})
@@ -0,0 +1,10 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package v1alpha1 contains the API types reported by the aws-custom-route-controller in the target cluster.
+// +k8s:deepcopy-gen=package
+// +groupName=routes.aws.gardener.cloud
+package v1alpha1
@@ -0,0 +1,90 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="VPC",type=string,JSONPath=`.status.vpcID`
+// +kubebuilder:printcolumn:name="Desired",type=integer,JSONPath=`.status.desiredRoutes`
+// +kubebuilder:printcolumn:name="Actual",type=integer,JSONPath=`.status.actualRoutes`
+// +kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+
+// RouteTableStatus reports the state of a route table managed by the aws-custom-route-controller.
+// The name of the object is the route table ID.
+type RouteTableStatus struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Status is the state of the route table as seen on the last sync.
+	Status RouteTableState `json:"status,omitempty"`
+}
+
+// RouteTableState is the state of a route table as seen on the last sync.
+type RouteTableState struct {
+	// TableID is the ID of the route table.
+	TableID string `json:"tableID"`
+	// VPCID is the ID of the VPC of the route table.
+	VPCID string `json:"vpcID,omitempty"`
+	// DesiredRoutes is the number of node routes which should exist in the route table.
+	DesiredRoutes int `json:"desiredRoutes"`
+	// ActualRoutes is the number of node routes existing in the route table after the sync.
+	ActualRoutes int `json:"actualRoutes"`
+	// UnmanagedRoutes are routes in the pod network not managed by the controller.
+	// +optional
+	UnmanagedRoutes []UnmanagedRoute `json:"unmanagedRoutes,omitempty"`
+	// LastSyncTime is the time of the last sync. It is refreshed at least every 10 minutes if the state is unchanged.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// Errors contains the last error per destination.
+	// +optional
+	Errors []DestinationError `json:"errors,omitempty"`
+}
+
+// UnmanagedRoute is a route in the pod network not managed by the controller.
+type UnmanagedRoute struct {
+	// Destination is the destination CIDR of the route.
+	Destination string `json:"destination"`
+	// Target is the target of the route, e.g. an instance ID or a gateway ID.
+	// +optional
+	Target string `json:"target,omitempty"`
+	// Origin is the origin of the route.
+	// +optional
+	Origin string `json:"origin,omitempty"`
+}
+
+// DestinationError is the last error of a route operation for a destination.
+type DestinationError struct {
+	// Destination is the destination CIDR of the route.
+	Destination string `json:"destination"`
+	// Operation is the failed route operation.
+	Operation string `json:"operation"`
+	// Code is the AWS error code if available.
+	// +optional
+	Code string `json:"code,omitempty"`
+	// Message is the error message.
+	Message string `json:"message"`
+	// LastErrorTime is the time the error occurred first while it is repeated unchanged.
+	LastErrorTime metav1.Time `json:"lastErrorTime"`
+}
+
+// +kubebuilder:object:root=true
+
+// RouteTableStatusList is a list of RouteTableStatus objects.
+type RouteTableStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of RouteTableStatus objects.
+	Items []RouteTableStatus `json:"items"`
+}
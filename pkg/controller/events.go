@@ -0,0 +1,201 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/gardener/aws-custom-route-controller/pkg/updater"
+)
+
+const (
+	// EventReasonRouteCreated is the event reason for a successfully created route
+	EventReasonRouteCreated = "RouteCreated"
+	// EventReasonRouteCreateFailed is the event reason for a failed route creation
+	EventReasonRouteCreateFailed = "RouteCreateFailed"
+	// EventReasonRouteRemoved is the event reason for a successfully removed route
+	EventReasonRouteRemoved = "RouteRemoved"
+	// EventReasonRouteRemoveFailed is the event reason for a failed route removal
+	EventReasonRouteRemoveFailed = "RouteRemoveFailed"
+	// EventReasonRouteLimitApproaching is the event reason for a route table running out of routes
+	EventReasonRouteLimitApproaching = "RouteLimitApproaching"
+)
+
+// nodeEventDeduplicator remembers the last failure event sent per node, route table and operation
+// to avoid sending the same event on every retry.
+type nodeEventDeduplicator struct {
+	sync.Mutex
+	last map[nodeEventKey]string
+}
+
+type nodeEventKey struct {
+	nodeName    string
+	tableID     string
+	destination string
+	operation   updater.RouteOperation
+}
+
+func newNodeEventDeduplicator() *nodeEventDeduplicator {
+	return &nodeEventDeduplicator{
+		last: map[nodeEventKey]string{},
+	}
+}
+
+// reset forgets the last event sent for the key, e.g. after the operation succeeded.
+func (d *nodeEventDeduplicator) reset(key nodeEventKey) {
+	d.Lock()
+	defer d.Unlock()
+	delete(d.last, key)
+}
+
+// shouldSend returns true if the fingerprint differs from the last one sent for the key and records it.
+func (d *nodeEventDeduplicator) shouldSend(key nodeEventKey, fingerprint string) bool {
+	d.Lock()
+	defer d.Unlock()
+	if d.last[key] == fingerprint {
+		return false
+	}
+	d.last[key] = fingerprint
+	return true
+}
+
+// retainNodes forgets all entries of nodes not contained in the given set.
+func (d *nodeEventDeduplicator) retainNodes(nodeNames map[string]struct{}) {
+	d.Lock()
+	defer d.Unlock()
+	for key := range d.last {
+		if _, ok := nodeNames[key.nodeName]; !ok {
+			delete(d.last, key)
+		}
+	}
+}
+
+// reportRouteEvents emits events on the nodes affected by the route operations. Routes to an instance are linked to
+// the node by its provider ID, so that removed routes of a node with a changed pod CIDR are reported, too. Other routes,
+// e.g. to VPC peering connections, are linked by the pod CIDR of the node.
+func (r *NodeReconciler) reportRouteEvents(ctx context.Context, outcomes []updater.RouteOutcome) {
+	nodeList := &corev1.NodeList{}
+	if err := r.client.List(ctx, nodeList); err != nil {
+		r.log.Error(err, "failed to list nodes for route events")
+		return
+	}
+
+	instanceToNode := make(map[string]*corev1.Node)
+	podCIDRToNode := make(map[string]*corev1.Node)
+	nodeNames := make(map[string]struct{})
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		nodeNames[node.Name] = struct{}{}
+		if instanceID := updater.InstanceID(node); instanceID != "" {
+			instanceToNode[instanceID] = node
+		}
+		for _, podCIDR := range node.Spec.PodCIDRs {
+			podCIDRToNode[podCIDR] = node
+		}
+	}
+	r.nodeEvents.retainNodes(nodeNames)
+
+	for _, outcome := range outcomes {
+		var node *corev1.Node
+		if outcome.InstanceID != "" {
+			node = instanceToNode[outcome.InstanceID]
+		} else {
+			node = podCIDRToNode[outcome.Destination]
+		}
+		if node == nil {
+			// the node of the route is already deleted
+			continue
+		}
+		eventType, reason, note := routeEvent(outcome)
+		key := nodeEventKey{
+			nodeName:    node.Name,
+			tableID:     outcome.TableID,
+			destination: outcome.Destination,
+			operation:   outcome.Operation,
+		}
+		if outcome.Err == nil {
+			// successful operations are not repeated, a later failure of the same operation is reported again
+			r.nodeEvents.reset(key)
+		} else if !r.nodeEvents.shouldSend(key, reason+"/"+outcome.ErrorCode()) {
+			continue
+		}
+		r.recorder.Eventf(node, nil, eventType, reason, "Routing", "%s", note)
+	}
+}
+
+func routeEvent(outcome updater.RouteOutcome) (eventType, reason, note string) {
+	switch outcome.Operation {
+	case updater.RouteOperationDelete:
+		if outcome.Err == nil {
+			return corev1.EventTypeNormal, EventReasonRouteRemoved,
+				fmt.Sprintf("route %s removed from table %s", outcome.Destination, outcome.TableID)
+		}
+		return corev1.EventTypeWarning, EventReasonRouteRemoveFailed,
+			fmt.Sprintf("removing route %s from table %s failed (%s)", outcome.Destination, outcome.TableID, errorSummary(outcome))
+	default:
+		if outcome.Err == nil {
+			return corev1.EventTypeNormal, EventReasonRouteCreated,
+				fmt.Sprintf("route %s -> %s created in table %s", outcome.Destination, routeTarget(outcome), outcome.TableID)
+		}
+		return corev1.EventTypeWarning, EventReasonRouteCreateFailed,
+			fmt.Sprintf("creating route %s -> %s in table %s failed (%s)", outcome.Destination, routeTarget(outcome), outcome.TableID, errorSummary(outcome))
+	}
+}
+
+// routeTarget returns the VPC peering connection of a route for a node in a peered VPC or the node instance
+func routeTarget(outcome updater.RouteOutcome) string {
+	if outcome.VPCPeeringConnectionID != "" {
+		return outcome.VPCPeeringConnectionID
+	}
+	return outcome.InstanceID
+}
+
+// errorSummary returns the failure reason and AWS error code of the outcome, e.g. "Throttled: RequestLimitExceeded, retrying"
+func errorSummary(outcome updater.RouteOutcome) string {
+	code := outcome.ErrorCode()
+	if code == "" {
+		code = "unknown error"
+	}
+	summary := updater.ErrorReason(outcome.Err) + ": " + code
+	if updater.IsTransient(outcome.Err) {
+		summary += ", retrying"
+	}
+	return summary
+}
+
+// reportRouteLimits emits a warning event for route tables whose headroom is at or below the warning headroom.
+// A table is reported again only if its headroom shrinks further.
+func (r *NodeReconciler) reportRouteLimits(tables []updater.RouteTableResult) {
+	warningHeadroom := int(r.routeLimitWarningHeadroom.Load())
+	current := map[string]struct{}{}
+	for _, table := range tables {
+		headroom, ok := table.Headroom()
+		if !ok {
+			continue
+		}
+		current[table.TableID] = struct{}{}
+		if headroom > warningHeadroom {
+			delete(r.routeLimitWarnings, table.TableID)
+			continue
+		}
+		if last, ok := r.routeLimitWarnings[table.TableID]; ok && headroom >= last {
+			continue
+		}
+		r.routeLimitWarnings[table.TableID] = headroom
+		r.recorder.Eventf(controllerObjectReference(), nil, corev1.EventTypeWarning, EventReasonRouteLimitApproaching, "Reconciling",
+			"route table %s has %d of %d routes, %d routes left before the route limit is reached", table.TableID, table.TotalRoutes, table.RouteLimit, headroom)
+	}
+	for tableID := range r.routeLimitWarnings {
+		if _, ok := current[tableID]; !ok {
+			delete(r.routeLimitWarnings, tableID)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/gardener/aws-custom-route-controller/pkg/updater"
+)
+
+// recordedEvents collects the events as "<object name> <reason>"
+type recordedEvents []string
+
+func (e *recordedEvents) Eventf(regarding runtime.Object, _ runtime.Object, _, reason, _, _ string, _ ...any) {
+	*e = append(*e, fmt.Sprintf("%s %s", regarding.(metav1.Object).GetName(), reason))
+}
+
+var _ = Describe("Route events", func() {
+	var (
+		ctx        = context.Background()
+		events     *recordedEvents
+		reconciler *NodeReconciler
+	)
+
+	BeforeEach(func() {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+			Spec: corev1.NodeSpec{
+				ProviderID: "aws:///eu-west-1a/i-1",
+				PodCIDRs:   []string{"10.243.5.0/24"},
+			},
+		}
+		events = &recordedEvents{}
+		reconciler = NewNodeReconciler(fake.NewClientBuilder().WithObjects(node).Build(), logf.Log, nil, events)
+	})
+
+	It("should link routes to the node by instance ID", func() {
+		reconciler.reportRouteEvents(ctx, []updater.RouteOutcome{
+			{Operation: updater.RouteOperationDelete, TableID: "rt1", Destination: "10.243.1.0/24", InstanceID: "i-1"},
+			{Operation: updater.RouteOperationCreate, TableID: "rt1", Destination: "10.243.5.0/24", InstanceID: "i-1"},
+			{Operation: updater.RouteOperationDelete, TableID: "rt1", Destination: "10.243.2.0/24", InstanceID: "i-deleted"},
+			{Operation: updater.RouteOperationCreate, TableID: "rt2", Destination: "10.243.5.0/24", VPCPeeringConnectionID: "pcx-1"},
+		})
+		Expect(*events).To(Equal(recordedEvents{
+			"node1 " + EventReasonRouteRemoved,
+			"node1 " + EventReasonRouteCreated,
+			"node1 " + EventReasonRouteCreated,
+		}))
+	})
+
+	It("should report repeated failures once until the operation succeeds", func() {
+		failed := updater.RouteOutcome{Operation: updater.RouteOperationDelete, TableID: "rt1", Destination: "10.243.1.0/24", InstanceID: "i-1",
+			Err: &smithy.GenericAPIError{Code: "UnauthorizedOperation"}}
+		succeeded := failed
+		succeeded.Err = nil
+		reconciler.reportRouteEvents(ctx, []updater.RouteOutcome{failed})
+		reconciler.reportRouteEvents(ctx, []updater.RouteOutcome{failed})
+		reconciler.reportRouteEvents(ctx, []updater.RouteOutcome{succeeded})
+		reconciler.reportRouteEvents(ctx, []updater.RouteOutcome{failed})
+		Expect(*events).To(Equal(recordedEvents{
+			"node1 " + EventReasonRouteRemoveFailed,
+			"node1 " + EventReasonRouteRemoved,
+			"node1 " + EventReasonRouteRemoveFailed,
+		}))
+	})
+})
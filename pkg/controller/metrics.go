@@ -0,0 +1,61 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/gardener/aws-custom-route-controller/pkg/updater"
+)
+
+const metricsNamespace = "aws_custom_route_controller"
+
+var (
+	routeTableRouteLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "route_table_route_limit",
+		Help:      "Maximum number of routes per route table.",
+	}, []string{"route_table"})
+	routeTableRoutes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "route_table_routes",
+		Help:      "Number of routes in the route table counting towards the route limit.",
+	}, []string{"route_table"})
+	routeTableHeadroom = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "route_table_route_headroom",
+		Help:      "Number of routes which can still be created in the route table before reaching the route limit.",
+	}, []string{"route_table"})
+	timeToRouteSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "time_to_route_seconds",
+		Help:      "Time from the first appearance of a node pod CIDR until its routes were confirmed in all route tables.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(routeTableRouteLimit, routeTableRoutes, routeTableHeadroom, timeToRouteSeconds)
+}
+
+// recordRouteTableMetrics replaces the route table metrics with the tables of the last update.
+// Tables with unknown route limit are skipped.
+func recordRouteTableMetrics(tables []updater.RouteTableResult) {
+	routeTableRouteLimit.Reset()
+	routeTableRoutes.Reset()
+	routeTableHeadroom.Reset()
+	for _, table := range tables {
+		headroom, ok := table.Headroom()
+		if !ok {
+			continue
+		}
+		routeTableRouteLimit.WithLabelValues(table.TableID).Set(float64(table.RouteLimit))
+		routeTableRoutes.WithLabelValues(table.TableID).Set(float64(table.TotalRoutes))
+		routeTableHeadroom.WithLabelValues(table.TableID).Set(float64(headroom))
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/events"
+
+	"github.com/gardener/aws-custom-route-controller/pkg/updater"
+)
+
+const (
+	// EventReasonPreflightSucceeded is the event reason for passed preflight checks
+	EventReasonPreflightSucceeded = "PreflightSucceeded"
+	// EventReasonPreflightFailed is the event reason for failed preflight checks
+	EventReasonPreflightFailed = "PreflightFailed"
+
+	// preflightRetryPeriod is the period for repeating failed preflight checks, e.g. until IAM changes are effective
+	preflightRetryPeriod = 1 * time.Minute
+)
+
+// PreflightFunc runs the preflight checks
+type PreflightFunc func(ctx context.Context) *updater.PreflightResult
+
+// Preflight runs the preflight checks on startup and reports the result through readyz and an event.
+// Failed checks are repeated until they pass.
+type Preflight struct {
+	log       logr.Logger
+	recorder  events.EventRecorder
+	preflight PreflightFunc
+
+	lock sync.Mutex
+	err  error
+}
+
+// NewPreflight creates a Preflight instance
+func NewPreflight(log logr.Logger, recorder events.EventRecorder, preflight PreflightFunc) *Preflight {
+	return &Preflight{
+		log:       log.WithName("preflight"),
+		recorder:  recorder,
+		preflight: preflight,
+		err:       fmt.Errorf("preflight checks not finished"),
+	}
+}
+
+// Start runs the preflight checks until they pass. It implements manager.Runnable.
+func (p *Preflight) Start(ctx context.Context) error {
+	for {
+		if p.run(ctx) == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(preflightRetryPeriod):
+		}
+	}
+}
+
+// NeedLeaderElection returns false, as the checks are read-only and readiness is needed on all replicas
+func (p *Preflight) NeedLeaderElection() bool {
+	return false
+}
+
+// ReadyChecker fails until all preflight checks have passed
+func (p *Preflight) ReadyChecker(_ *http.Request) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.err
+}
+
+func (p *Preflight) run(ctx context.Context) error {
+	result := p.preflight(ctx)
+	for _, check := range result.Checks {
+		if check.Err != nil {
+			p.log.Error(check.Err, "preflight check failed", "check", check.Name)
+		} else {
+			p.log.Info("preflight check passed", "check", check.Name, "message", check.Message)
+		}
+	}
+	err := result.Err()
+
+	p.lock.Lock()
+	changed := p.err == nil || err == nil || p.err.Error() != err.Error()
+	p.err = err
+	p.lock.Unlock()
+
+	if changed {
+		if err == nil {
+			p.recorder.Eventf(controllerObjectReference(), nil, corev1.EventTypeNormal, EventReasonPreflightSucceeded, "Preflight", "all preflight checks passed")
+		} else {
+			p.recorder.Eventf(controllerObjectReference(), nil, corev1.EventTypeWarning, EventReasonPreflightFailed, "Preflight", "%s", truncateMessage(err.Error()))
+		}
+	}
+	return err
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -48,10 +49,20 @@ type NodeReconciler struct {
 	elected            <-chan struct{}
 	nodeRoutes         *updater.NamedNodeRoutes
 	lastTick           atomic.Time
-	tickPeriod         time.Duration
+	tickPeriod         atomic.Duration
+	syncPeriod         atomic.Duration
+	maxDelayOnFailure  atomic.Duration
+	readySyncPeriods   atomic.Int32
+	state              updaterState
+
+	routeLimitWarningHeadroom atomic.Int32
+	timeToRouteAnnotation     atomic.Bool
+	// routeLimitWarnings is the headroom reported last per route table, only accessed by the updater loop
+	routeLimitWarnings map[string]int
 
 	recorder    events.EventRecorder
 	lastEventOk bool
+	nodeEvents  *nodeEventDeduplicator
 }
 
 // NewNodeReconciler creates a NodeReconciler instance
@@ -67,13 +78,16 @@ func NewNodeReconciler(
 		elected:    elected,
 		nodeRoutes: updater.NewNamedNodeRoutes(),
 		recorder:   recorder,
+		nodeEvents: newNodeEventDeduplicator(),
+
+		routeLimitWarnings: map[string]int{},
 	}
 }
 
 // StartUpdater starts background go routine to check for changed routes calculated by watching nodes
 func (r *NodeReconciler) StartUpdater(ctx context.Context, updateFunc updater.NodeRoutesUpdater,
 	tickPeriod, syncPeriod, maxDelayOnFailure time.Duration) {
-	r.tickPeriod = tickPeriod
+	r.SetUpdaterTimings(tickPeriod, syncPeriod, maxDelayOnFailure)
 	ticker := time.NewTicker(tickPeriod)
 	log := r.log.WithName("ticker")
 
@@ -82,6 +96,7 @@ func (r *NodeReconciler) StartUpdater(ctx context.Context, updateFunc updater.No
 			lastUpdate  time.Time
 			lastFailure time.Time
 			delay       time.Duration
+			nextRetry   time.Time
 		)
 
 		r.updaterStarted.Store(true)
@@ -92,10 +107,15 @@ func (r *NodeReconciler) StartUpdater(ctx context.Context, updateFunc updater.No
 				log.Info("updater loop cancelled")
 				return
 			}
+			if period := r.tickPeriod.Load(); period != tickPeriod {
+				log.Info("tick period changed", "tickPeriod", period)
+				tickPeriod = period
+				ticker.Reset(tickPeriod)
+			}
 			if !r.initialiseFinished.Load() {
 				continue
 			}
-			if lastUpdate.Add(syncPeriod).Before(time.Now()) {
+			if lastUpdate.Add(r.syncPeriod.Load()).Before(time.Now()) {
 				log.Info("sync")
 				r.nodeRoutes.SetChanged()
 			}
@@ -103,36 +123,104 @@ func (r *NodeReconciler) StartUpdater(ctx context.Context, updateFunc updater.No
 				log.Info("retry")
 				r.nodeRoutes.SetChanged()
 			}
+			if !nextRetry.IsZero() && !nextRetry.After(time.Now()) {
+				log.Info("retry failed routes")
+				r.nodeRoutes.SetChanged()
+			}
 			if routes := r.nodeRoutes.GetRoutesIfChanged(); routes != nil {
 				result, err := updateFunc(ctx, routes, func() { r.lastTick.Store(time.Now()) })
+				nextRetry = time.Time{}
+				if result != nil {
+					nextRetry = result.NextRetry
+				}
+				if updater.OnlyDestinationErrors(err) {
+					// failing routes are retried with their own backoff, the other routes are up-to-date
+					log.Info("updating some routes failed", "error", err.Error())
+					err = nil
+				}
 				if err != nil {
 					log.Error(err, "updating routes failed")
 					lastFailure = time.Now()
-					if delay == 0 {
-						delay = tickPeriod
-					} else {
-						delay = 4 * delay / 3
-						if delay > maxDelayOnFailure {
-							delay = maxDelayOnFailure
-						}
-					}
+					delay = nextDelay(err, delay, tickPeriod, r.maxDelayOnFailure.Load())
 				} else {
 					delay = 0
 				}
+				tableCount := 0
+				var quarantined []string
+				if result != nil {
+					tableCount = len(result.Tables)
+					quarantined = result.Quarantined
+				}
+				r.state.recordSync(err, delay, tableCount, quarantined)
 				r.reportEventIfNeeded(err)
 
 				// Update node conditions based on route creation results
 				if result != nil {
 					r.updateNodeConditions(ctx, routes, result)
+					r.reportRouteEvents(ctx, result.Outcomes)
+				}
+				if result != nil && len(result.Tables) > 0 {
+					recordRouteTableMetrics(result.Tables)
+					r.reportRouteLimits(result.Tables)
+				}
+				if result != nil {
+					r.recordTimeToRoute(ctx, result)
 				}
 
 				lastUpdate = time.Now()
 			}
+			if conflicts, changed := r.nodeRoutes.GetConflictsIfChanged(); changed {
+				r.reportConflicts(ctx, conflicts)
+			}
 			r.lastTick.Store(time.Now())
 		}
 	}()
 }
 
+// nextDelay returns the delay before retrying a failed update. The delay grows from the tick period up to the maximum
+// delay, permanent AWS errors are retried after the maximum delay.
+func nextDelay(err error, delay, tickPeriod, maxDelay time.Duration) time.Duration {
+	switch {
+	case updater.IsPermanentAPIError(err):
+		// retrying soon will not help, e.g. for missing permissions
+		return maxDelay
+	case delay == 0:
+		return tickPeriod
+	default:
+		return min(4*delay/3, maxDelay)
+	}
+}
+
+// SetUpdaterTimings sets the timings of the updater loop. It may be called while the updater is running.
+// A changed tick period is applied after the next tick.
+func (r *NodeReconciler) SetUpdaterTimings(tickPeriod, syncPeriod, maxDelayOnFailure time.Duration) {
+	r.tickPeriod.Store(tickPeriod)
+	r.syncPeriod.Store(syncPeriod)
+	r.maxDelayOnFailure.Store(maxDelayOnFailure)
+}
+
+// SetReadySyncPeriods sets the number of sync periods within which the last route update must have succeeded
+// for the controller to be ready.
+func (r *NodeReconciler) SetReadySyncPeriods(periods int) {
+	r.readySyncPeriods.Store(int32(periods))
+}
+
+// SetRouteLimitWarningHeadroom sets the number of routes left in a route table at which a warning event is emitted
+func (r *NodeReconciler) SetRouteLimitWarningHeadroom(headroom int) {
+	r.routeLimitWarningHeadroom.Store(int32(headroom))
+}
+
+// SetTimeToRouteAnnotation enables writing the time to route as annotation on new nodes
+func (r *NodeReconciler) SetTimeToRouteAnnotation(enabled bool) {
+	r.timeToRouteAnnotation.Store(enabled)
+}
+
+// SetPodNetworks sets the pod networks used to validate the node pod CIDRs.
+// It triggers a route update and may be called while the updater is running.
+func (r *NodeReconciler) SetPodNetworks(podNetworkCIDRs []string) error {
+	return r.nodeRoutes.SetPodNetworks(podNetworkCIDRs)
+}
+
 func (r *NodeReconciler) reportEventIfNeeded(err error) {
 	isOk := err == nil
 	if isOk && r.lastEventOk {
@@ -140,24 +228,31 @@ func (r *NodeReconciler) reportEventIfNeeded(err error) {
 		return
 	}
 
-	// An object is needed this event is about.
-	// As the aws-custom-route-controller has not many objects in the shoot cluster, just use its ServiceAccount.
-	ref := &corev1.ObjectReference{
+	ref := controllerObjectReference()
+	if isOk {
+		r.recorder.Eventf(ref, nil, corev1.EventTypeNormal, "RoutesUpToDate", "Reconciling", "routes for all route tables are up-to-date")
+	} else {
+		r.recorder.Eventf(ref, nil, corev1.EventTypeWarning, "RoutesUpdateFailed", "Reconciling", "%s", truncateMessage(err.Error()))
+	}
+	r.lastEventOk = isOk
+}
+
+// controllerObjectReference returns the object controller-wide events are about.
+// As the aws-custom-route-controller has not many objects in the shoot cluster, just use its ServiceAccount.
+func controllerObjectReference() *corev1.ObjectReference {
+	return &corev1.ObjectReference{
 		Kind:       "ServiceAccount",
 		APIVersion: "v1",
 		Namespace:  metav1.NamespaceSystem,
 		Name:       "aws-custom-route-controller",
 	}
-	if isOk {
-		r.recorder.Eventf(ref, nil, corev1.EventTypeNormal, "RoutesUpToDate", "Reconciling", "routes for all route tables are up-to-date")
-	} else {
-		msg := err.Error()
-		if len(msg) > 300 {
-			msg = msg[:300] + "..."
-		}
-		r.recorder.Eventf(ref, nil, corev1.EventTypeWarning, "RoutesUpdateFailed", "Reconciling", msg)
+}
+
+func truncateMessage(msg string) string {
+	if len(msg) > 300 {
+		return msg[:300] + "..."
 	}
-	r.lastEventOk = isOk
+	return msg
 }
 
 // Reconcile extracts pod cidrs from nodes
@@ -181,13 +276,51 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req reconcile.Request) (
 	return reconcile.Result{}, nil
 }
 
+// ReadyChecker checks that this instance is the leader and the last route update succeeded within
+// the configured number of sync periods. Without any node routes there is nothing to sync.
 func (r *NodeReconciler) ReadyChecker(_ *http.Request) error {
 	if !r.updaterStarted.Load() {
 		return fmt.Errorf("updater not started")
 	}
+	if !r.isElected() {
+		return fmt.Errorf("not elected as leader")
+	}
+	if !r.initialiseFinished.Load() {
+		return fmt.Errorf("initialise not finished")
+	}
+	if r.nodeRoutes.Len() == 0 {
+		return nil
+	}
+
+	r.state.Lock()
+	lastSuccessfulSync, lastErr := r.state.lastSuccessfulSync, r.state.lastErr
+	r.state.Unlock()
+	if lastSuccessfulSync.IsZero() {
+		if lastErr != nil {
+			return fmt.Errorf("no successful sync yet: %w", lastErr)
+		}
+		return fmt.Errorf("no successful sync yet")
+	}
+	maxAge := time.Duration(r.readySyncPeriods.Load()) * r.syncPeriod.Load()
+	if maxAge > 0 && lastSuccessfulSync.Add(maxAge).Before(time.Now()) {
+		err := fmt.Errorf("last successful sync at %s is older than %s", lastSuccessfulSync.Format(time.RFC3339), maxAge)
+		if lastErr != nil {
+			return fmt.Errorf("%w: %w", err, lastErr)
+		}
+		return err
+	}
 	return nil
 }
 
+func (r *NodeReconciler) isElected() bool {
+	select {
+	case <-r.elected:
+		return true
+	default:
+		return false
+	}
+}
+
 func (r *NodeReconciler) HealthzChecker(_ *http.Request) error {
 	err := r.healthzChecker()
 	if err != nil {
@@ -209,7 +342,7 @@ func (r *NodeReconciler) healthzChecker() error {
 		}
 		return fmt.Errorf("initialise not finished")
 	}
-	if r.lastTick.Load().Add(5 * r.tickPeriod).Before(time.Now()) {
+	if r.lastTick.Load().Add(5 * r.tickPeriod.Load()).Before(time.Now()) {
 		return fmt.Errorf("missing tick")
 	}
 	return nil
@@ -225,6 +358,8 @@ func (r *NodeReconciler) initialise(ctx context.Context) {
 	for _, node := range nodeList.Items {
 		r.addNodeRoute(&node)
 	}
+	// the time to route is only measured for nodes added after the start
+	r.nodeRoutes.StartTimeToRoute()
 	r.initialiseFinished.Store(true)
 	r.log.Info("initialise finished")
 }
@@ -241,46 +376,45 @@ func (r *NodeReconciler) removeNodeRoute(nodeName string) {
 	}
 }
 
+// recordTimeToRoute observes the time to route of all new node pod CIDRs confirmed by the update result
+// and optionally writes it as annotation on the node
+func (r *NodeReconciler) recordTimeToRoute(ctx context.Context, result *updater.RouteUpdateResult) {
+	for _, ttr := range r.nodeRoutes.ConfirmRoutes(result, time.Now()) {
+		timeToRouteSeconds.Observe(ttr.Duration.Seconds())
+		r.log.Info("node routes confirmed", "node", ttr.NodeName, "podCIDR", ttr.PodCIDR, "timeToRoute", ttr.Duration)
+		if !r.timeToRouteAnnotation.Load() {
+			continue
+		}
+		value := ttr.Duration.Round(time.Millisecond).String()
+		if err := util.SetNodeAnnotation(ctx, r.client, types.NodeName(ttr.NodeName), updater.TimeToRouteAnnotation, value); err != nil {
+			r.log.Error(err, "failed to annotate node with time to route", "node", ttr.NodeName)
+		}
+	}
+}
+
 // updateNetworkingCondition updates the NetworkUnavailable condition for a node based on route creation status
-func (r *NodeReconciler) updateNetworkingCondition(ctx context.Context, node *corev1.Node, routesCreated bool) error {
+func (r *NodeReconciler) updateNetworkingCondition(ctx context.Context, node *corev1.Node, status corev1.ConditionStatus, reason, message string) error {
 	_, condition := util.GetNodeCondition(&node.Status, corev1.NodeNetworkUnavailable)
 
-	if routesCreated && condition != nil && condition.Status == corev1.ConditionFalse && condition.Reason == "RouteCreated" {
-		r.log.Info("set node with NodeNetworkUnavailable=false was canceled because it is already set", "node", node.Name)
+	if isConditionSet(condition, status, reason, message) {
+		r.log.Info(fmt.Sprintf("set node with NodeNetworkUnavailable=%s was canceled because it is already set", strings.ToLower(string(status))), "node", node.Name)
 		return nil
 	}
 
-	if !routesCreated && condition != nil && condition.Status == corev1.ConditionTrue && condition.Reason == "NoRouteCreated" {
-		r.log.Info("set node with NodeNetworkUnavailable=true was canceled because it is already set", "node", node.Name)
-		return nil
-	}
-
-	r.log.Info("patching node status", "node", node.Name, "routesCreated", routesCreated, "previousCondition", fmt.Sprintf("%+v", condition))
+	r.log.Info("patching node status", "node", node.Name, "status", status, "reason", reason, "previousCondition", fmt.Sprintf("%+v", condition))
 
 	// either condition is not there, or has a value != to what we need
 	// start setting it
 	err := wait.ExponentialBackoff(updateNetworkConditionBackoff, func() (bool, error) {
-		var err error
 		// Patch could also fail, even though the chance is very slim. So we still do
 		// patch in the retry loop.
-		currentTime := metav1.Now()
-		if routesCreated {
-			err = util.SetNodeCondition(ctx, r.client, types.NodeName(node.Name), corev1.NodeCondition{
-				Type:               corev1.NodeNetworkUnavailable,
-				Status:             corev1.ConditionFalse,
-				Reason:             "RouteCreated",
-				Message:            "RouteController created a route",
-				LastTransitionTime: currentTime,
-			})
-		} else {
-			err = util.SetNodeCondition(ctx, r.client, types.NodeName(node.Name), corev1.NodeCondition{
-				Type:               corev1.NodeNetworkUnavailable,
-				Status:             corev1.ConditionTrue,
-				Reason:             "NoRouteCreated",
-				Message:            "RouteController failed to create a route",
-				LastTransitionTime: currentTime,
-			})
-		}
+		err := util.SetNodeCondition(ctx, r.client, types.NodeName(node.Name), corev1.NodeCondition{
+			Type:               corev1.NodeNetworkUnavailable,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: metav1.Now(),
+		})
 		if err != nil {
 			r.log.V(4).Info("error updating node condition, retrying", "node", node.Name, "error", err.Error())
 			return false, nil
@@ -316,10 +450,41 @@ func (r *NodeReconciler) updateNodeConditions(ctx context.Context, routes []upda
 	// Update conditions for each route
 	for _, route := range routes {
 		if node, ok := podCIDRToNode[route.PodCIDR]; ok {
-			routeSuccess := result.SuccessfulRoutes[route.PodCIDR]
-			if err := r.updateNetworkingCondition(ctx, node, routeSuccess); err != nil {
+			status, reason, message := corev1.ConditionFalse, "RouteCreated", "RouteController created a route"
+			if failure := result.Failures[route.PodCIDR]; failure != nil {
+				status, reason, message = corev1.ConditionTrue, failure.Reason, failure.Message
+			} else if !result.SuccessfulRoutes[route.PodCIDR] {
+				status, reason, message = corev1.ConditionTrue, "NoRouteCreated", "RouteController failed to create a route"
+			}
+			if err := r.updateNetworkingCondition(ctx, node, status, reason, message); err != nil {
 				r.log.Error(err, "failed to update node condition", "node", node.Name, "podCIDR", route.PodCIDR)
 			}
 		}
 	}
 }
+
+// reportConflicts sets the NetworkUnavailable condition and emits a warning event for nodes with conflicting pod CIDRs
+func (r *NodeReconciler) reportConflicts(ctx context.Context, conflicts []updater.RouteConflict) {
+	for _, conflict := range conflicts {
+		node := &corev1.Node{}
+		if err := r.client.Get(ctx, client.ObjectKey{Name: conflict.NodeName}, node); err != nil {
+			if !errors.IsNotFound(err) {
+				r.log.Error(err, "failed to get node for conflict report", "node", conflict.NodeName)
+			}
+			continue
+		}
+		_, condition := util.GetNodeCondition(&node.Status, corev1.NodeNetworkUnavailable)
+		if isConditionSet(condition, corev1.ConditionTrue, conflict.Reason, conflict.Message) {
+			continue
+		}
+		r.log.Info("excluded node route because of conflict", "node", conflict.NodeName, "podCIDR", conflict.Route.PodCIDR, "reason", conflict.Reason, "message", conflict.Message)
+		r.recorder.Eventf(node, nil, corev1.EventTypeWarning, conflict.Reason, "Validating", "%s", conflict.Message)
+		if err := r.updateNetworkingCondition(ctx, node, corev1.ConditionTrue, conflict.Reason, conflict.Message); err != nil {
+			r.log.Error(err, "failed to update node condition", "node", conflict.NodeName, "podCIDR", conflict.Route.PodCIDR)
+		}
+	}
+}
+
+func isConditionSet(condition *corev1.NodeCondition, status corev1.ConditionStatus, reason, message string) bool {
+	return condition != nil && condition.Status == status && condition.Reason == reason && condition.Message == message
+}
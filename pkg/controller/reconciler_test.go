@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Updater backoff", func() {
+	const (
+		tickPeriod = 5 * time.Second
+		maxDelay   = 5 * time.Minute
+	)
+
+	It("should back off exponentially on errors without AWS error code", func() {
+		for _, err := range []error{
+			errors.New("transit gateway route 10.243.0.0/19 in table tgw-rtb-1 points at another attachment"),
+			fmt.Errorf("describing route tables failed: %w", context.DeadlineExceeded),
+		} {
+			Expect(nextDelay(err, 0, tickPeriod, maxDelay)).To(Equal(tickPeriod))
+			Expect(nextDelay(err, 6*time.Second, tickPeriod, maxDelay)).To(Equal(8 * time.Second))
+			Expect(nextDelay(err, 4*time.Minute, tickPeriod, maxDelay)).To(Equal(maxDelay))
+		}
+	})
+
+	It("should back off exponentially on transient AWS errors", func() {
+		Expect(nextDelay(&smithy.GenericAPIError{Code: "RequestLimitExceeded"}, 0, tickPeriod, maxDelay)).To(Equal(tickPeriod))
+	})
+
+	It("should retry permanent AWS errors after the maximum delay", func() {
+		Expect(nextDelay(&smithy.GenericAPIError{Code: "UnauthorizedOperation"}, 0, tickPeriod, maxDelay)).To(Equal(maxDelay))
+	})
+})
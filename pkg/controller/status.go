@@ -0,0 +1,115 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// UpdaterStatus is the status of the updater loop served as JSON on the status endpoint
+type UpdaterStatus struct {
+	// Elected is true if this instance is the leader
+	Elected bool `json:"elected"`
+	// Initialised is true if the initial node list has been processed
+	Initialised bool `json:"initialised"`
+	// LastSyncTime is the time of the last route update
+	LastSyncTime *time.Time `json:"lastSyncTime,omitempty"`
+	// LastSuccessfulSyncTime is the time of the last route update without error
+	LastSuccessfulSyncTime *time.Time `json:"lastSuccessfulSyncTime,omitempty"`
+	// LastError is the error of the last route update if it failed
+	LastError string `json:"lastError,omitempty"`
+	// ConsecutiveFailures is the number of failed route updates since the last successful one
+	ConsecutiveFailures int `json:"consecutiveFailures"`
+	// RetryDelay is the current delay for retrying a failed route update
+	RetryDelay string `json:"retryDelay,omitempty"`
+	// NextRetryTime is the earliest time of the next retry
+	NextRetryTime *time.Time `json:"nextRetryTime,omitempty"`
+	// NodeCount is the number of nodes with a pod CIDR
+	NodeCount int `json:"nodeCount"`
+	// ConflictCount is the number of nodes excluded because of conflicting pod CIDRs
+	ConflictCount int `json:"conflictCount"`
+	// RouteTableCount is the number of route tables found on the last route update
+	RouteTableCount int `json:"routeTableCount"`
+	// QuarantinedRoutes are the pod CIDRs of node routes which are not retried for the quarantine period
+	QuarantinedRoutes []string `json:"quarantinedRoutes,omitempty"`
+}
+
+// updaterState keeps the state of the updater loop needed for readiness and the status endpoint
+type updaterState struct {
+	sync.Mutex
+	lastSync            time.Time
+	lastSuccessfulSync  time.Time
+	lastErr             error
+	consecutiveFailures int
+	retryDelay          time.Duration
+	tableCount          int
+	quarantined         []string
+}
+
+// recordSync records the result of a route update
+func (s *updaterState) recordSync(err error, retryDelay time.Duration, tableCount int, quarantined []string) {
+	s.Lock()
+	defer s.Unlock()
+	s.lastSync = time.Now()
+	s.quarantined = quarantined
+	s.lastErr = err
+	s.retryDelay = retryDelay
+	if err != nil {
+		s.consecutiveFailures++
+	} else {
+		s.consecutiveFailures = 0
+		s.lastSuccessfulSync = s.lastSync
+	}
+	if tableCount > 0 || err == nil {
+		s.tableCount = tableCount
+	}
+}
+
+// Status returns the status of the updater loop
+func (r *NodeReconciler) Status() UpdaterStatus {
+	status := UpdaterStatus{
+		Elected:       r.isElected(),
+		Initialised:   r.initialiseFinished.Load(),
+		NodeCount:     r.nodeRoutes.Len(),
+		ConflictCount: len(r.nodeRoutes.GetConflicts()),
+	}
+
+	r.state.Lock()
+	defer r.state.Unlock()
+	if !r.state.lastSync.IsZero() {
+		status.LastSyncTime = new(r.state.lastSync)
+	}
+	if !r.state.lastSuccessfulSync.IsZero() {
+		status.LastSuccessfulSyncTime = new(r.state.lastSuccessfulSync)
+	}
+	if r.state.lastErr != nil {
+		status.LastError = r.state.lastErr.Error()
+	}
+	status.ConsecutiveFailures = r.state.consecutiveFailures
+	if r.state.retryDelay > 0 {
+		status.RetryDelay = r.state.retryDelay.String()
+		status.NextRetryTime = new(r.state.lastSync.Add(r.state.retryDelay))
+	}
+	status.RouteTableCount = r.state.tableCount
+	status.QuarantinedRoutes = r.state.quarantined
+	return status
+}
+
+// StatusHandler serves the status of the updater loop as JSON
+func (r *NodeReconciler) StatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(r.Status()); err != nil {
+			r.log.Error(err, "writing status failed")
+		}
+	})
+}
@@ -0,0 +1,159 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/multierr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/aws-custom-route-controller/pkg/apis/routes/v1alpha1"
+	"github.com/gardener/aws-custom-route-controller/pkg/updater"
+)
+
+// refreshInterval is the maximum age of the last sync time of an otherwise unchanged RouteTableStatus
+const refreshInterval = 10 * time.Minute
+
+// RouteTableStatusReporter keeps a RouteTableStatus object per route table up-to-date
+type RouteTableStatusReporter struct {
+	client client.Client
+}
+
+var _ updater.StatusReporter = (*RouteTableStatusReporter)(nil)
+
+// NewRouteTableStatusReporter creates a new RouteTableStatusReporter.
+// The client needs a scheme with the v1alpha1 types and should not be backed by a cache.
+func NewRouteTableStatusReporter(client client.Client) *RouteTableStatusReporter {
+	return &RouteTableStatusReporter{
+		client: client,
+	}
+}
+
+// ReportStatus creates or updates the RouteTableStatus objects for all route tables of the result
+// and deletes the objects of route tables which are not managed anymore.
+// Objects are only updated if the state changed or the last sync time is older than the refresh interval.
+func (r *RouteTableStatusReporter) ReportStatus(ctx context.Context, result *updater.RouteUpdateResult) error {
+	list := &v1alpha1.RouteTableStatusList{}
+	if err := r.client.List(ctx, list); err != nil {
+		return fmt.Errorf("listing route table status failed: %w", err)
+	}
+	existing := map[string]*v1alpha1.RouteTableStatus{}
+	for i := range list.Items {
+		existing[list.Items[i].Name] = &list.Items[i]
+	}
+
+	var errs error
+	now := metav1.Now()
+	current := map[string]struct{}{}
+	for _, table := range result.Tables {
+		current[table.TableID] = struct{}{}
+		obj := existing[table.TableID]
+		var previous v1alpha1.RouteTableState
+		if obj != nil {
+			previous = obj.Status
+		}
+		state := v1alpha1.RouteTableState{
+			TableID:       table.TableID,
+			VPCID:         table.VPCID,
+			DesiredRoutes: table.DesiredRoutes,
+			ActualRoutes:  table.ActualRoutes,
+			LastSyncTime:  previous.LastSyncTime,
+			Errors:        mergeErrors(previous.Errors, table.TableID, result, now),
+		}
+		for _, route := range table.UnmanagedRoutes {
+			state.UnmanagedRoutes = append(state.UnmanagedRoutes, v1alpha1.UnmanagedRoute{
+				Destination: route.Destination,
+				Target:      route.Target,
+				Origin:      route.Origin,
+			})
+		}
+		if obj != nil && equality.Semantic.DeepEqual(state, previous) &&
+			previous.LastSyncTime != nil && now.Sub(previous.LastSyncTime.Time) < refreshInterval {
+			continue
+		}
+		state.LastSyncTime = &now
+		if err := r.updateStatus(ctx, obj, table.TableID, state); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("updating route table status %s failed: %w", table.TableID, err))
+		}
+	}
+
+	for name, obj := range existing {
+		if _, ok := current[name]; ok {
+			continue
+		}
+		if err := r.client.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			errs = multierr.Append(errs, fmt.Errorf("deleting route table status %s failed: %w", name, err))
+		}
+	}
+	return errs
+}
+
+// mergeErrors returns the errors of the route table after the update. The error of a destination is replaced by a new
+// failure and kept until an operation on the destination succeeds, the node route is confirmed or the destination is
+// neither a node pod CIDR nor failing anymore.
+func mergeErrors(previous []v1alpha1.DestinationError, tableID string, result *updater.RouteUpdateResult, now metav1.Time) []v1alpha1.DestinationError {
+	last := map[string]v1alpha1.DestinationError{}
+	errors := map[string]v1alpha1.DestinationError{}
+	for _, destinationError := range previous {
+		last[destinationError.Destination] = destinationError
+		if successful, ok := result.SuccessfulRoutes[destinationError.Destination]; ok && !successful {
+			errors[destinationError.Destination] = destinationError
+		}
+	}
+	for _, outcome := range result.Outcomes {
+		if outcome.TableID != tableID {
+			continue
+		}
+		if outcome.Err == nil {
+			delete(errors, outcome.Destination)
+			continue
+		}
+		destinationError := v1alpha1.DestinationError{
+			Destination:   outcome.Destination,
+			Operation:     string(outcome.Operation),
+			Code:          outcome.ErrorCode(),
+			Message:       outcome.ErrorMessage(),
+			LastErrorTime: now,
+		}
+		if prev, ok := last[outcome.Destination]; ok && prev.Operation == destinationError.Operation &&
+			prev.Code == destinationError.Code && prev.Message == destinationError.Message {
+			// keep the time of an unchanged error to avoid updating the object on every retry
+			destinationError.LastErrorTime = prev.LastErrorTime
+		}
+		errors[outcome.Destination] = destinationError
+	}
+
+	var merged []v1alpha1.DestinationError
+	for _, destinationError := range errors {
+		merged = append(merged, destinationError)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Destination < merged[j].Destination
+	})
+	return merged
+}
+
+func (r *RouteTableStatusReporter) updateStatus(ctx context.Context, obj *v1alpha1.RouteTableStatus, name string, state v1alpha1.RouteTableState) error {
+	if obj == nil {
+		obj = &v1alpha1.RouteTableStatus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		}
+		if err := r.client.Create(ctx, obj); err != nil {
+			return err
+		}
+	}
+	obj.Status = state
+	return r.client.Status().Update(ctx, obj)
+}
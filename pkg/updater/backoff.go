@@ -0,0 +1,160 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package updater
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ReasonRouteQuarantined is the failure reason for a node route which is not retried for the quarantine period
+// after persistent failures
+const ReasonRouteQuarantined = "RouteQuarantined"
+
+// routeBackoff tracks the failures of node routes per destination
+type routeBackoff struct {
+	sync.Mutex
+	initialDelay     time.Duration
+	maxDelay         time.Duration
+	quarantineAfter  int
+	quarantinePeriod time.Duration
+	entries          map[string]*routeBackoffEntry
+}
+
+type routeBackoffEntry struct {
+	instanceID  string
+	failures    int
+	delay       time.Duration
+	nextAttempt time.Time
+	lastErr     *RouteError
+}
+
+func newRouteBackoff() *routeBackoff {
+	return &routeBackoff{
+		entries: map[string]*routeBackoffEntry{},
+	}
+}
+
+// SetRouteBackoff configures the backoff of failing node routes. The delay starts with initialDelay and grows up to
+// maxDelay. After quarantineAfter consecutive failures the route is quarantined and only retried after the
+// quarantine period. If quarantineAfter is zero, routes are never quarantined.
+func (r *CustomRoutes) SetRouteBackoff(initialDelay, maxDelay time.Duration, quarantineAfter int, quarantinePeriod time.Duration) {
+	r.backoff.Lock()
+	defer r.backoff.Unlock()
+	r.backoff.initialDelay = initialDelay
+	r.backoff.maxDelay = maxDelay
+	r.backoff.quarantineAfter = quarantineAfter
+	r.backoff.quarantinePeriod = quarantinePeriod
+}
+
+// retain forgets the entries of destinations without node route or with a changed instance
+func (b *routeBackoff) retain(routes []NodeRoute) {
+	b.Lock()
+	defer b.Unlock()
+	current := make(map[string]string, len(routes))
+	for _, route := range routes {
+		current[route.PodCIDR] = route.InstanceID
+	}
+	for destination, entry := range b.entries {
+		if instanceID, ok := current[destination]; !ok || instanceID != entry.instanceID {
+			delete(b.entries, destination)
+		}
+	}
+}
+
+// blocked returns true and the last failure if the creation of the route must not be attempted now
+func (b *routeBackoff) blocked(route internalNodeRoute, now time.Time) (bool, *RouteFailure) {
+	b.Lock()
+	defer b.Unlock()
+	entry, ok := b.entries[route.destinationCidrBlock]
+	if !ok || !now.Before(entry.nextAttempt) {
+		return false, nil
+	}
+	reason := entry.lastErr.Reason()
+	if b.isQuarantined(entry) {
+		reason = ReasonRouteQuarantined
+	}
+	return true, &RouteFailure{
+		Reason: reason,
+		Message: fmt.Sprintf("route creation failed %d times, next attempt at %s: %s",
+			entry.failures, entry.nextAttempt.Format(time.RFC3339), awsErrorMessage(entry.lastErr.Err)),
+	}
+}
+
+// record updates the entries with the errors of all attempted destinations. A nil error resets the entry.
+// Transient errors are retried with the backoff of the update and leave the entry unchanged.
+func (b *routeBackoff) record(attempts map[string]*RouteError, now time.Time) {
+	b.Lock()
+	defer b.Unlock()
+	for destination, attempt := range attempts {
+		if attempt == nil {
+			delete(b.entries, destination)
+			continue
+		}
+		if attempt.Transient() {
+			continue
+		}
+		entry, ok := b.entries[destination]
+		if !ok {
+			entry = &routeBackoffEntry{instanceID: attempt.InstanceID}
+			b.entries[destination] = entry
+		}
+		entry.failures++
+		entry.lastErr = attempt
+		if entry.delay == 0 {
+			entry.delay = b.initialDelay
+		} else {
+			entry.delay = min(4*entry.delay/3, b.maxDelay)
+		}
+		entry.nextAttempt = now.Add(entry.delay)
+		if b.isQuarantined(entry) {
+			entry.nextAttempt = now.Add(b.quarantinePeriod)
+		}
+	}
+}
+
+// nextRetry returns the earliest time a blocked destination can be retried or zero if there is none
+func (b *routeBackoff) nextRetry() time.Time {
+	b.Lock()
+	defer b.Unlock()
+	var next time.Time
+	for _, entry := range b.entries {
+		if next.IsZero() || entry.nextAttempt.Before(next) {
+			next = entry.nextAttempt
+		}
+	}
+	return next
+}
+
+// quarantined returns the sorted quarantined destinations
+func (b *routeBackoff) quarantined() []string {
+	b.Lock()
+	defer b.Unlock()
+	var destinations []string
+	for destination, entry := range b.entries {
+		if b.isQuarantined(entry) {
+			destinations = append(destinations, destination)
+		}
+	}
+	sort.Strings(destinations)
+	return destinations
+}
+
+func (b *routeBackoff) isQuarantined(entry *routeBackoffEntry) bool {
+	return b.quarantineAfter > 0 && entry.failures >= b.quarantineAfter
+}
+
+func sortedDestinations(attempts map[string]*RouteError) []string {
+	destinations := make([]string, 0, len(attempts))
+	for destination := range attempts {
+		destinations = append(destinations, destination)
+	}
+	sort.Strings(destinations)
+	return destinations
+}
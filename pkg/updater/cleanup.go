@@ -0,0 +1,68 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package updater
+
+import (
+	"context"
+
+	"go.uber.org/multierr"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Cleanup deletes all routes managed by the controller from all route tables of the cluster.
+// Failed deletions are retried with the given backoff unless all errors are permanent AWS errors. The result contains the
+// outcomes of the last attempt and the state of the route tables after it.
+func (r *CustomRoutes) Cleanup(ctx context.Context, backoff wait.Backoff) (*RouteUpdateResult, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	result := &RouteUpdateResult{}
+	var lastErr error
+	err := wait.ExponentialBackoffWithContext(ctx, backoff, func(ctx context.Context) (bool, error) {
+		// only the last attempt is reported
+		*result = RouteUpdateResult{
+			SuccessfulRoutes: make(map[string]bool),
+			Failures:         make(map[string]*RouteFailure),
+		}
+		lastErr = r.deleteAllRoutes(ctx, result)
+		if IsPermanentAPIError(lastErr) {
+			return false, lastErr
+		}
+		if lastErr != nil {
+			r.log.Info("cleanup incomplete, retrying", "error", lastErr.Error())
+			return false, nil
+		}
+		return true, nil
+	})
+	if lastErr != nil {
+		return result, lastErr
+	}
+	return result, err
+}
+
+func (r *CustomRoutes) deleteAllRoutes(ctx context.Context, result *RouteUpdateResult) error {
+	tables, err := r.findRouteTables(ctx)
+	if err != nil {
+		return err
+	}
+
+	var cleanupErrors error
+	for _, table := range tables {
+		_, toBeDeleted := r.calcRouteChanges(table, tablePlan{})
+		tableResult := r.newRouteTableResult(table, tablePlan{})
+		start := len(result.Outcomes)
+		cleanupErrors = multierr.Append(cleanupErrors, r.deleteRoutes(ctx, table, toBeDeleted, result, func() {}))
+		for _, outcome := range result.Outcomes[start:] {
+			if outcome.Err == nil {
+				tableResult.ActualRoutes--
+			}
+		}
+		result.Tables = append(result.Tables, tableResult)
+	}
+	cleanupErrors = multierr.Append(cleanupErrors, r.deleteTransitGatewayRoutes(ctx, result))
+	return cleanupErrors
+}
@@ -7,14 +7,18 @@
 package updater
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -26,12 +30,42 @@ const (
 	SecretAccessKey = "secretAccessKey"
 	// WorkloadIdentityTokenFile is a constant for the key in a cloud provider secret and backup secret that holds the path to a workload identity token.
 	WorkloadIdentityTokenFile = "workloadIdentityTokenFile"
+	// WorkloadIdentityServiceAccount is a constant for the key in a cloud provider secret that holds the service account ('<namespace>/<name>' or '<name>'
+	// in the namespace of the secret) for which workload identity tokens are requested with the TokenRequest API.
+	WorkloadIdentityServiceAccount = "workloadIdentityServiceAccount"
+	// WorkloadIdentityAudience is a constant for the key in a cloud provider secret that holds the audience of the requested workload identity tokens.
+	WorkloadIdentityAudience = "workloadIdentityAudience"
+	// DefaultWorkloadIdentityAudience is the default audience of the requested workload identity tokens.
+	DefaultWorkloadIdentityAudience = "sts.amazonaws.com"
 	// RoleARN is a constant for the key in a cloud provider secret and backup secret that holds ARN of a role that is to be assumed.
 	RoleARN = "roleARN"
+	// AssumeRoleARN is a constant for the key in a cloud provider secret that holds the ARN of a role that is assumed with the credentials, e.g. in another account.
+	AssumeRoleARN = "assumeRoleARN"
+	// ExternalID is a constant for the key in a cloud provider secret that holds the external ID used for assuming the role.
+	ExternalID = "externalID"
+	// SessionName is a constant for the key in a cloud provider secret that holds the session name used for assuming the role.
+	SessionName = "sessionName"
 	// InClusterConfig is a special name for the kubeconfig to use in-cluster client
 	InClusterConfig = "inClusterConfig"
+
+	// CredentialsSourceControlSecret loads the credentials from a secret on the control plane.
+	CredentialsSourceControlSecret = "control-secret"
+	// CredentialsSourceTargetSecret loads the credentials from a secret in the target cluster.
+	CredentialsSourceTargetSecret = "target-secret"
+	// CredentialsSourceDirectory loads the credentials from a directory with a file per secret data key.
+	CredentialsSourceDirectory = "directory"
+	// CredentialsSourceDefaultChain uses the AWS default credential chain (environment, shared config/profile, IMDS).
+	CredentialsSourceDefaultChain = "default-chain"
 )
 
+// AllCredentialsSources is a slice of all available credentials sources.
+var AllCredentialsSources = []string{
+	CredentialsSourceControlSecret,
+	CredentialsSourceTargetSecret,
+	CredentialsSourceDirectory,
+	CredentialsSourceDefaultChain,
+}
+
 type Credentials struct {
 	// AccessKey represents static credentials for authentication to AWS.
 	// This field is mutually exclusive with WorkloadIdentity.
@@ -40,6 +74,13 @@ type Credentials struct {
 	// WorkloadIdentity contains workload identity configuration.
 	// This field is mutually exclusive with AccessKey.
 	WorkloadIdentity *WorkloadIdentity
+
+	// DefaultChain selects the AWS default credential chain.
+	// This field is mutually exclusive with AccessKey and WorkloadIdentity.
+	DefaultChain bool
+
+	// AssumeRole is an optional role assumed with the credentials given by AccessKey or WorkloadIdentity.
+	AssumeRole *AssumeRole
 }
 
 // AccessKey represents static credentials for authentication to AWS.
@@ -59,13 +100,24 @@ type WorkloadIdentity struct {
 	RoleARN string
 }
 
-func LoadCredentials(controlKubeconfig, namespace, secretName string) (*Credentials, error) {
+// AssumeRole contains the configuration for assuming a role, e.g. for route tables in another account.
+type AssumeRole struct {
+	// RoleARN is the ARN of the role to assume.
+	RoleARN string
+	// ExternalID is the optional external ID required by the trust policy of the role.
+	ExternalID string
+	// SessionName is the optional name of the role session.
+	SessionName string
+}
+
+// LoadCredentials loads the credentials from the secret in the cluster of the given kubeconfig
+func LoadCredentials(kubeconfig, namespace, secretName string) (*Credentials, error) {
 	var err error
 	var config *rest.Config
-	if controlKubeconfig == InClusterConfig || controlKubeconfig == "" {
+	if kubeconfig == InClusterConfig || kubeconfig == "" {
 		config, err = rest.InClusterConfig()
 	} else {
-		config, err = clientcmd.BuildConfigFromFlags("", controlKubeconfig)
+		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
 	}
 	if err != nil {
 		return nil, err
@@ -81,18 +133,116 @@ func LoadCredentials(controlKubeconfig, namespace, secretName string) (*Credenti
 		return nil, err
 	}
 
-	creds, err := extractCredentials(secret)
+	creds, err := extractCredentials(secret, clientset.CoreV1())
+	if err != nil {
+		return nil, err
+	}
+	return creds, nil
+}
+
+// LoadCredentialsFromDirectory loads the credentials from a directory containing a file per secret data key,
+// e.g. a mounted secret.
+func LoadCredentialsFromDirectory(dir string) (*Credentials, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{Data: map[string][]byte{}}
+	for _, entry := range entries {
+		// skip the hidden entries of mounted secrets, e.g. ..data
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		value, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		secret.Data[entry.Name()] = bytes.TrimSpace(value)
+	}
+	return extractCredentials(secret, nil)
+}
+
+// DefaultChainCredentials returns the credentials using the AWS default credential chain
+func DefaultChainCredentials() *Credentials {
+	return &Credentials{DefaultChain: true}
+}
+
+// extractCredentials extracts the credentials from the secret data.
+// The service accounts client is used for requesting workload identity tokens and may be nil if not supported by the source.
+func extractCredentials(secret *corev1.Secret, serviceAccounts corev1client.ServiceAccountsGetter) (*Credentials, error) {
+	creds, err := extractBaseCredentials(secret, serviceAccounts)
+	if err != nil {
+		return nil, err
+	}
+
+	assumeRole, err := extractAssumeRole(secret)
 	if err != nil {
 		return nil, err
 	}
+	creds.AssumeRole = assumeRole
 	return creds, nil
 }
 
-func extractCredentials(secret *corev1.Secret) (*Credentials, error) {
+func extractAssumeRole(secret *corev1.Secret) (*AssumeRole, error) {
+	assumeRoleARN := string(secret.Data[AssumeRoleARN])
+	externalID := string(secret.Data[ExternalID])
+	sessionName := string(secret.Data[SessionName])
+	if assumeRoleARN == "" {
+		if externalID != "" || sessionName != "" {
+			return nil, fmt.Errorf("%s and %s require %s", ExternalID, SessionName, AssumeRoleARN)
+		}
+		return nil, nil
+	}
+	return &AssumeRole{
+		RoleARN:     assumeRoleARN,
+		ExternalID:  externalID,
+		SessionName: sessionName,
+	}, nil
+}
+
+func extractBaseCredentials(secret *corev1.Secret, serviceAccounts corev1client.ServiceAccountsGetter) (*Credentials, error) {
 	if secret.Data == nil {
 		return nil, fmt.Errorf("secret does not contain any data")
 	}
 
+	if serviceAccount, ok := secret.Data[WorkloadIdentityServiceAccount]; ok {
+		if len(serviceAccount) == 0 {
+			return nil, fmt.Errorf("workloadIdentityServiceAccount must not be empty")
+		}
+		if serviceAccounts == nil {
+			return nil, fmt.Errorf("workloadIdentityServiceAccount is only supported for credentials loaded from a secret")
+		}
+
+		roleARN, ok := secret.Data[RoleARN]
+		if !ok || len(roleARN) == 0 {
+			return nil, fmt.Errorf("roleARN is required")
+		}
+
+		namespace, name := secret.Namespace, string(serviceAccount)
+		if parts := strings.SplitN(name, "/", 2); len(parts) == 2 {
+			namespace, name = parts[0], parts[1]
+		}
+		audience := DefaultWorkloadIdentityAudience
+		if value := secret.Data[WorkloadIdentityAudience]; len(value) > 0 {
+			audience = string(value)
+		}
+
+		return &Credentials{
+			WorkloadIdentity: &WorkloadIdentity{
+				TokenRetriever: NewTokenRequestRetriever(serviceAccounts, namespace, name, audience),
+				RoleARN:        string(roleARN),
+			},
+		}, nil
+	}
+
 	if workloadIdentityTokenFile, ok := secret.Data[WorkloadIdentityTokenFile]; ok {
 		if len(workloadIdentityTokenFile) == 0 {
 			return nil, fmt.Errorf("workloadIdentityTokenFile must not be empty")
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package updater_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/aws-custom-route-controller/pkg/updater"
+)
+
+var _ = Describe("Credentials", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	writeFile := func(name, value string) {
+		Expect(os.WriteFile(filepath.Join(dir, name), []byte(value), 0600)).To(Succeed())
+	}
+
+	It("should load access key and assume role from a directory", func() {
+		writeFile(updater.AccessKeyID, "id\n")
+		writeFile(updater.SecretAccessKey, "secret\n")
+		writeFile(updater.AssumeRoleARN, "arn:aws:iam::123456789012:role/route-manager")
+		writeFile(updater.ExternalID, "external")
+		Expect(os.Mkdir(filepath.Join(dir, "..data"), 0700)).To(Succeed())
+
+		creds, err := updater.LoadCredentialsFromDirectory(dir)
+		Expect(err).To(BeNil())
+		Expect(creds.AccessKey).To(Equal(&updater.AccessKey{ID: "id", Secret: "secret"}))
+		Expect(creds.AssumeRole).To(Equal(&updater.AssumeRole{
+			RoleARN:    "arn:aws:iam::123456789012:role/route-manager",
+			ExternalID: "external",
+		}))
+	})
+
+	It("should require the role ARN for an external ID", func() {
+		writeFile(updater.AccessKeyID, "id")
+		writeFile(updater.SecretAccessKey, "secret")
+		writeFile(updater.ExternalID, "external")
+
+		_, err := updater.LoadCredentialsFromDirectory(dir)
+		Expect(err).To(MatchError(ContainSubstring(updater.AssumeRoleARN)))
+	})
+
+	It("should fail without credentials", func() {
+		_, err := updater.LoadCredentialsFromDirectory(dir)
+		Expect(err).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,86 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package updater
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// RouteTableDiff is the difference between the desired and the actual routes of a route table
+type RouteTableDiff struct {
+	TableID string `json:"tableID"`
+	VPCID   string `json:"vpcID,omitempty"`
+	// VPCPeeringConnectionIDs maps the pod CIDRs of nodes in peered VPCs to the target VPC peering connection
+	VPCPeeringConnectionIDs map[string]string `json:"vpcPeeringConnectionIDs,omitempty"`
+	ToBeCreated             []NodeRoute       `json:"toBeCreated,omitempty"`
+	ToBeDeleted             []NodeRoute       `json:"toBeDeleted,omitempty"`
+	Unchanged               []NodeRoute       `json:"unchanged,omitempty"`
+	UnmanagedRoutes         []UnmanagedRoute  `json:"unmanagedRoutes,omitempty"`
+}
+
+// Diff calculates the route changes an update would apply for the given node routes without changing anything
+func (r *CustomRoutes) Diff(ctx context.Context, routes []NodeRoute) ([]RouteTableDiff, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	routes = r.filterProtectedRoutes(routes, &RouteUpdateResult{Failures: map[string]*RouteFailure{}})
+
+	tables, err := r.findRouteTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, _, err := r.planRouteTables(ctx, tables, routes)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []RouteTableDiff
+	for i, table := range tables {
+		toBeCreated, toBeDeleted := r.calcRouteChanges(table, plans[i])
+		diff := RouteTableDiff{
+			TableID:         aws.ToString(table.RouteTableId),
+			VPCID:           aws.ToString(table.VpcId),
+			UnmanagedRoutes: r.newRouteTableResult(table, plans[i]).UnmanagedRoutes,
+		}
+		created := map[string]bool{}
+		for _, create := range toBeCreated {
+			created[create.destinationCidrBlock] = true
+			diff.ToBeCreated = append(diff.ToBeCreated, create.toNodeRoute())
+		}
+		for _, del := range toBeDeleted {
+			diff.ToBeDeleted = append(diff.ToBeDeleted, del.toNodeRoute())
+		}
+		if !r.isMainTable(table) {
+			for _, route := range plans[i].nodeRoutes {
+				if !created[route.PodCIDR] {
+					diff.Unchanged = append(diff.Unchanged, route)
+				}
+			}
+			for _, route := range plans[i].peeringRoutes {
+				if diff.VPCPeeringConnectionIDs == nil {
+					diff.VPCPeeringConnectionIDs = map[string]string{}
+				}
+				diff.VPCPeeringConnectionIDs[route.destinationCidrBlock] = route.vpcPeeringConnectionId
+				if !created[route.destinationCidrBlock] {
+					diff.Unchanged = append(diff.Unchanged, route.toNodeRoute())
+				}
+			}
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+func (r internalNodeRoute) toNodeRoute() NodeRoute {
+	return NodeRoute{
+		InstanceID: r.instanceId,
+		PodCIDR:    r.destinationCidrBlock,
+	}
+}
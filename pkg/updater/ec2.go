@@ -7,8 +7,11 @@
 package updater
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"os"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	v2config "github.com/aws/aws-sdk-go-v2/config"
@@ -38,33 +41,136 @@ type EC2Routes interface {
 	CreateRoute(ctx context.Context, params *ec2.CreateRouteInput, optFns ...func(*ec2.Options)) (*ec2.CreateRouteOutput, error)
 	DeleteRoute(ctx context.Context, params *ec2.DeleteRouteInput, optFns ...func(*ec2.Options)) (*ec2.DeleteRouteOutput, error)
 	DescribeInstances(ctx context.Context, params *ec2.DescribeInstancesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error)
+	DescribeVpcPeeringConnections(ctx context.Context, params *ec2.DescribeVpcPeeringConnectionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcPeeringConnectionsOutput, error)
+	SearchTransitGatewayRoutes(ctx context.Context, params *ec2.SearchTransitGatewayRoutesInput, optFns ...func(*ec2.Options)) (*ec2.SearchTransitGatewayRoutesOutput, error)
+	CreateTransitGatewayRoute(ctx context.Context, params *ec2.CreateTransitGatewayRouteInput, optFns ...func(*ec2.Options)) (*ec2.CreateTransitGatewayRouteOutput, error)
+	ReplaceTransitGatewayRoute(ctx context.Context, params *ec2.ReplaceTransitGatewayRouteInput, optFns ...func(*ec2.Options)) (*ec2.ReplaceTransitGatewayRouteOutput, error)
+	DeleteTransitGatewayRoute(ctx context.Context, params *ec2.DeleteTransitGatewayRouteInput, optFns ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayRouteOutput, error)
+	DescribeManagedPrefixLists(ctx context.Context, params *ec2.DescribeManagedPrefixListsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeManagedPrefixListsOutput, error)
+	GetManagedPrefixListEntries(ctx context.Context, params *ec2.GetManagedPrefixListEntriesInput, optFns ...func(*ec2.Options)) (*ec2.GetManagedPrefixListEntriesOutput, error)
+	ModifyManagedPrefixList(ctx context.Context, params *ec2.ModifyManagedPrefixListInput, optFns ...func(*ec2.Options)) (*ec2.ModifyManagedPrefixListOutput, error)
+	DescribeSecurityGroups(ctx context.Context, params *ec2.DescribeSecurityGroupsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error)
+	DescribeSecurityGroupRules(ctx context.Context, params *ec2.DescribeSecurityGroupRulesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupRulesOutput, error)
+	AuthorizeSecurityGroupIngress(ctx context.Context, params *ec2.AuthorizeSecurityGroupIngressInput, optFns ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupIngressOutput, error)
+	RevokeSecurityGroupIngress(ctx context.Context, params *ec2.RevokeSecurityGroupIngressInput, optFns ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error)
 }
 
-func NewAWSEC2Routes(creds *Credentials, region string) (EC2Routes, error) {
+// CallerIdentity is an abstraction over AWS STS, to allow mocking/other implementations
+//
+//go:generate ${MOCKGEN} -destination=mock_sts.go -package=updater github.com/gardener/aws-custom-route-controller/pkg/updater CallerIdentity
+type CallerIdentity interface {
+	GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+// AWSClientOptions configures the endpoints and transport of the AWS clients
+type AWSClientOptions struct {
+	// Region is the AWS region.
+	Region string
+	// EC2Endpoint overrides the URL of the EC2 endpoint, e.g. for LocalStack.
+	EC2Endpoint string
+	// STSEndpoint overrides the URL of the STS endpoint. It is also used for web identity.
+	STSEndpoint string
+	// UseFIPSEndpoint selects the FIPS endpoints.
+	UseFIPSEndpoint bool
+	// UseDualStackEndpoint selects the dual-stack endpoints.
+	UseDualStackEndpoint bool
+	// CABundle is the path of a PEM file with additional CA certificates.
+	CABundle string
+}
+
+func NewAWSEC2Routes(creds *Credentials, opts AWSClientOptions) (EC2Routes, error) {
+	cfg, err := newAWSConfig(creds, opts)
+	if err != nil {
+		return nil, err
+	}
+	return ec2.NewFromConfig(cfg, opts.ec2Options), nil
+}
+
+// NewAWSCallerIdentity creates the STS client used to check the caller identity
+func NewAWSCallerIdentity(creds *Credentials, opts AWSClientOptions) (CallerIdentity, error) {
+	cfg, err := newAWSConfig(creds, opts)
+	if err != nil {
+		return nil, err
+	}
+	return sts.NewFromConfig(cfg, opts.stsOptions), nil
+}
+
+func newAWSConfig(creds *Credentials, opts AWSClientOptions) (aws.Config, error) {
+	loadOptions := []func(*v2config.LoadOptions) error{
+		v2config.WithRegion(opts.Region),
+	}
+	if opts.UseFIPSEndpoint {
+		loadOptions = append(loadOptions, v2config.WithUseFIPSEndpoint(aws.FIPSEndpointStateEnabled))
+	}
+	if opts.UseDualStackEndpoint {
+		loadOptions = append(loadOptions, v2config.WithUseDualStackEndpoint(aws.DualStackEndpointStateEnabled))
+	}
+	if opts.CABundle != "" {
+		caBundle, err := os.ReadFile(opts.CABundle)
+		if err != nil {
+			return aws.Config{}, fmt.Errorf("could not read CA bundle: %w", err)
+		}
+		loadOptions = append(loadOptions, v2config.WithCustomCABundle(bytes.NewReader(caBundle)))
+	}
+
+	cfg, err := v2config.LoadDefaultConfig(context.TODO(), loadOptions...)
+	if err != nil {
+		return aws.Config{}, err
+	}
+
 	var credentialsProvider aws.CredentialsProvider
 	switch {
+	case creds.DefaultChain:
+		// the credentials of the default chain are already loaded and cached
 	case creds.AccessKey != nil:
 		credentialsProvider = credentials.NewStaticCredentialsProvider(creds.AccessKey.ID, creds.AccessKey.Secret, "")
 	case creds.WorkloadIdentity != nil:
+		// the STS client for web identity uses the same endpoints and transport as the other clients,
+		// but no credentials of the default chain
+		stsConfig := cfg.Copy()
+		stsConfig.Credentials = aws.AnonymousCredentials{}
 		credentialsProvider = stscreds.NewWebIdentityRoleProvider(
-			sts.NewFromConfig(aws.Config{Region: region}),
+			sts.NewFromConfig(stsConfig, opts.stsOptions),
 			creds.WorkloadIdentity.RoleARN,
 			creds.WorkloadIdentity.TokenRetriever,
 		)
 	default:
-		return nil, errors.New("credentials should either contain access key or workload identity config")
+		return aws.Config{}, errors.New("credentials should either contain access key or workload identity config or use the default chain")
+	}
+	if credentialsProvider != nil {
+		cfg.Credentials = aws.NewCredentialsCache(credentialsProvider)
 	}
 
-	cfg, err := v2config.LoadDefaultConfig(
-		context.TODO(),
-		v2config.WithRegion(region),
-		v2config.WithCredentialsProvider(aws.NewCredentialsCache(credentialsProvider)),
-	)
-	if err != nil {
-		return nil, err
+	if creds.AssumeRole != nil {
+		// role chaining: the role is assumed with the credentials above
+		assumeRole := creds.AssumeRole
+		credentialsProvider = stscreds.NewAssumeRoleProvider(
+			sts.NewFromConfig(cfg, opts.stsOptions),
+			assumeRole.RoleARN,
+			func(o *stscreds.AssumeRoleOptions) {
+				if assumeRole.ExternalID != "" {
+					o.ExternalID = aws.String(assumeRole.ExternalID)
+				}
+				if assumeRole.SessionName != "" {
+					o.RoleSessionName = assumeRole.SessionName
+				}
+			},
+		)
+		cfg.Credentials = aws.NewCredentialsCache(credentialsProvider)
+	}
+	return cfg, nil
+}
+
+func (o AWSClientOptions) ec2Options(options *ec2.Options) {
+	if o.EC2Endpoint != "" {
+		options.BaseEndpoint = aws.String(o.EC2Endpoint)
 	}
+}
 
-	return ec2.NewFromConfig(cfg), nil
+func (o AWSClientOptions) stsOptions(options *sts.Options) {
+	if o.STSEndpoint != "" {
+		options.BaseEndpoint = aws.String(o.STSEndpoint)
+	}
 }
 
 func ClusterTagKey(clusterID string) string {
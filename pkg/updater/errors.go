@@ -0,0 +1,232 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go"
+	"go.uber.org/multierr"
+)
+
+const (
+	// ReasonThrottled is the failure reason for a route operation rejected by the AWS API rate limits
+	ReasonThrottled = "Throttled"
+	// ReasonServiceUnavailable is the failure reason for a route operation failed by an AWS server or network error
+	ReasonServiceUnavailable = "ServiceUnavailable"
+	// ReasonPermissionDenied is the failure reason for a route operation not permitted for the AWS credentials
+	ReasonPermissionDenied = "PermissionDenied"
+	// ReasonInstanceNotFound is the failure reason for a route to an instance or network interface which does not exist
+	ReasonInstanceNotFound = "InstanceNotFound"
+	// ReasonVPCPeeringMissing is the failure reason for the route of a node without active VPC peering connection
+	// between the VPC of the node and the VPC of a route table
+	ReasonVPCPeeringMissing = "VPCPeeringMissing"
+	// ReasonRouteOperationFailed is the failure reason for a route operation failed for any other reason
+	ReasonRouteOperationFailed = "RouteOperationFailed"
+)
+
+var (
+	throttlingErrorCodes = map[string]bool{
+		"RequestLimitExceeded":      true,
+		"Throttling":                true,
+		"ThrottlingException":       true,
+		"RequestThrottled":          true,
+		"RequestThrottledException": true,
+		"TooManyRequestsException":  true,
+	}
+	unavailableErrorCodes = map[string]bool{
+		"InternalError":      true,
+		"InternalFailure":    true,
+		"ServiceUnavailable": true,
+		"Unavailable":        true,
+		"RequestTimeout":     true,
+	}
+	permissionErrorCodes = map[string]bool{
+		"UnauthorizedOperation":       true,
+		"AuthFailure":                 true,
+		"AccessDenied":                true,
+		"AccessDeniedException":       true,
+		"UnrecognizedClientException": true,
+		"ExpiredToken":                true,
+		"ExpiredTokenException":       true,
+		"InvalidClientTokenId":        true,
+	}
+)
+
+// ErrInstanceNotFound is the error of a node route to an instance which does not exist
+var ErrInstanceNotFound = errors.New("instance not found")
+
+// RouteError is the error of an AWS operation on a route in a route table
+type RouteError struct {
+	Operation          RouteOperation
+	TableID            string
+	Destination        string
+	InstanceID         string
+	NetworkInterfaceID string
+	// VPCPeeringConnectionID is the target of a route for the pod CIDR of a node in a peered VPC
+	VPCPeeringConnectionID string
+	// TransitGatewayAttachmentID is the target of a pod network route in a transit gateway route table
+	TransitGatewayAttachmentID string
+	// Code is the AWS error code or empty if the operation failed without AWS response, e.g. because of a timeout
+	Code string
+	Err  error
+}
+
+func newRouteError(op RouteOperation, tableID string, route internalNodeRoute, networkInterfaceID string, err error) *RouteError {
+	return &RouteError{
+		Operation:                  op,
+		TableID:                    tableID,
+		Destination:                route.destinationCidrBlock,
+		InstanceID:                 route.instanceId,
+		NetworkInterfaceID:         networkInterfaceID,
+		VPCPeeringConnectionID:     route.vpcPeeringConnectionId,
+		TransitGatewayAttachmentID: route.transitGatewayAttachmentId,
+		Code:                       AWSErrorCode(err),
+		Err:                        err,
+	}
+}
+
+func (e *RouteError) Error() string {
+	return e.describe(e.Err.Error())
+}
+
+// Message returns the description of the error with the AWS error code and message only. Unlike Error, it does not
+// contain the request ID of the AWS call and stays the same if the operation fails repeatedly.
+func (e *RouteError) Message() string {
+	return e.describe(awsErrorMessage(e.Err))
+}
+
+func (e *RouteError) describe(cause string) string {
+	switch e.Operation {
+	case RouteOperationDelete:
+		return fmt.Sprintf("deleting route %s in table %s failed: %s", e.Destination, e.TableID, cause)
+	case RouteOperationReplace:
+		return fmt.Sprintf("replacing route %s -> %s in table %s failed: %s", e.Destination, e.target(), e.TableID, cause)
+	default:
+		return fmt.Sprintf("creating route %s -> %s in table %s failed: %s", e.Destination, e.target(), e.TableID, cause)
+	}
+}
+
+func (e *RouteError) Unwrap() error {
+	return e.Err
+}
+
+// Transient returns true if the operation may succeed if retried soon, e.g. after throttling
+func (e *RouteError) Transient() bool {
+	return isTransient(e.Code, e.Err)
+}
+
+// Reason returns the failure reason of the error, e.g. for node conditions
+func (e *RouteError) Reason() string {
+	return ErrorReason(e)
+}
+
+func (e *RouteError) target() string {
+	switch {
+	case e.NetworkInterfaceID != "":
+		return e.NetworkInterfaceID
+	case e.VPCPeeringConnectionID != "":
+		return e.VPCPeeringConnectionID
+	case e.InstanceID != "":
+		return e.InstanceID
+	default:
+		return e.TransitGatewayAttachmentID
+	}
+}
+
+// IsTransient returns true if any of the (combined) errors may succeed if retried soon.
+// Throttling, AWS server errors and errors without AWS response like timeouts are transient.
+func IsTransient(err error) bool {
+	for _, e := range multierr.Errors(err) {
+		if isTransient(AWSErrorCode(e), e) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPermanent returns true if the error is not nil and none of the (combined) errors is transient
+func IsPermanent(err error) bool {
+	return err != nil && !IsTransient(err)
+}
+
+// IsPermanentAPIError returns true if the error is not nil and consists of permanent AWS errors only, e.g. missing
+// permissions. Errors without AWS error code, e.g. a missing route table or a connection reset, may be resolved on the
+// next attempt.
+func IsPermanentAPIError(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, e := range multierr.Errors(err) {
+		if code := AWSErrorCode(e); code == "" || isTransient(code, e) {
+			return false
+		}
+	}
+	return true
+}
+
+// ErrorReason returns the failure reason for the AWS error code of the error
+func ErrorReason(err error) string {
+	code := AWSErrorCode(err)
+	switch {
+	case throttlingErrorCodes[code]:
+		return ReasonThrottled
+	case isTransient(code, err):
+		return ReasonServiceUnavailable
+	case permissionErrorCodes[code]:
+		return ReasonPermissionDenied
+	case code == "RouteLimitExceeded":
+		return ReasonRouteLimitExceeded
+	case errors.Is(err, ErrInstanceNotFound),
+		strings.HasPrefix(code, "InvalidInstanceID."),
+		strings.HasPrefix(code, "InvalidNetworkInterfaceID."):
+		return ReasonInstanceNotFound
+	default:
+		return ReasonRouteOperationFailed
+	}
+}
+
+// OnlyDestinationErrors returns true if the error is not nil and consists of permanent errors of node routes only.
+// These routes are retried with their own backoff, independent of the other routes.
+func OnlyDestinationErrors(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, e := range multierr.Errors(err) {
+		var routeErr *RouteError
+		if !errors.As(e, &routeErr) || routeErr.Operation != RouteOperationCreate || routeErr.InstanceID == "" || routeErr.Transient() {
+			return false
+		}
+	}
+	return true
+}
+
+// awsErrorMessage returns the AWS error code and message of the error or the error itself without AWS response
+func awsErrorMessage(err error) string {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+	if apiErr.ErrorMessage() == "" {
+		return apiErr.ErrorCode()
+	}
+	return fmt.Sprintf("%s: %s", apiErr.ErrorCode(), apiErr.ErrorMessage())
+}
+
+func isTransient(code string, err error) bool {
+	if code == "" {
+		// errors without AWS response, e.g. timeouts or connection resets
+		return errors.Is(err, context.DeadlineExceeded) ||
+			retry.IsErrorRetryables(retry.DefaultRetryables).IsErrorRetryable(err) == aws.TrueTernary
+	}
+	return throttlingErrorCodes[code] || unavailableErrorCodes[code]
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package updater_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/multierr"
+
+	"github.com/gardener/aws-custom-route-controller/pkg/updater"
+)
+
+var _ = Describe("Errors", func() {
+	var (
+		throttled    = &smithy.GenericAPIError{Code: "RequestLimitExceeded"}
+		unauthorized = &smithy.GenericAPIError{Code: "UnauthorizedOperation"}
+		notFound     = &smithy.GenericAPIError{Code: "InvalidInstanceID.NotFound"}
+	)
+
+	It("should classify AWS errors as transient or permanent", func() {
+		Expect(updater.IsTransient(throttled)).To(BeTrue())
+		Expect(updater.IsTransient(&smithy.GenericAPIError{Code: "InternalError"})).To(BeTrue())
+		Expect(updater.IsTransient(fmt.Errorf("describing route tables failed: %w", context.DeadlineExceeded))).To(BeTrue())
+		Expect(updater.IsPermanent(unauthorized)).To(BeTrue())
+		Expect(updater.IsPermanent(notFound)).To(BeTrue())
+		Expect(updater.IsPermanent(errors.New("route points at another attachment"))).To(BeTrue())
+		Expect(updater.IsPermanent(nil)).To(BeFalse())
+		Expect(updater.IsTransient(multierr.Combine(unauthorized, throttled))).To(BeTrue())
+		Expect(updater.IsPermanentAPIError(unauthorized)).To(BeTrue())
+		Expect(updater.IsPermanentAPIError(multierr.Combine(unauthorized, notFound))).To(BeTrue())
+		Expect(updater.IsPermanentAPIError(multierr.Combine(unauthorized, errors.New("route points at another attachment")))).To(BeFalse())
+		Expect(updater.IsPermanentAPIError(fmt.Errorf("describing route tables failed: %w", context.Canceled))).To(BeFalse())
+		Expect(updater.IsPermanentAPIError(nil)).To(BeFalse())
+	})
+
+	It("should return the failure reason of AWS errors", func() {
+		Expect(updater.ErrorReason(throttled)).To(Equal(updater.ReasonThrottled))
+		Expect(updater.ErrorReason(&smithy.GenericAPIError{Code: "ServiceUnavailable"})).To(Equal(updater.ReasonServiceUnavailable))
+		Expect(updater.ErrorReason(unauthorized)).To(Equal(updater.ReasonPermissionDenied))
+		Expect(updater.ErrorReason(notFound)).To(Equal(updater.ReasonInstanceNotFound))
+		Expect(updater.ErrorReason(fmt.Errorf("%w: i-1", updater.ErrInstanceNotFound))).To(Equal(updater.ReasonInstanceNotFound))
+		Expect(updater.ErrorReason(&smithy.GenericAPIError{Code: "InvalidParameterValue"})).To(Equal(updater.ReasonRouteOperationFailed))
+	})
+
+	It("should only treat permanent node route errors as destination errors", func() {
+		nodeRouteErr := &updater.RouteError{Operation: updater.RouteOperationCreate, TableID: "rt1", Destination: "10.243.0.0/24", InstanceID: "i-1", Code: "InvalidInstanceID.NotFound", Err: notFound}
+		Expect(nodeRouteErr.Error()).To(Equal("creating route 10.243.0.0/24 -> i-1 in table rt1 failed: api error InvalidInstanceID.NotFound: "))
+		Expect(updater.OnlyDestinationErrors(nodeRouteErr)).To(BeTrue())
+
+		throttledErr := &updater.RouteError{Operation: updater.RouteOperationCreate, TableID: "rt1", Destination: "10.243.1.0/24", InstanceID: "i-2", Code: "RequestLimitExceeded", Err: throttled}
+		Expect(throttledErr.Transient()).To(BeTrue())
+		Expect(updater.OnlyDestinationErrors(multierr.Combine(nodeRouteErr, throttledErr))).To(BeFalse())
+
+		deleteErr := &updater.RouteError{Operation: updater.RouteOperationDelete, TableID: "rt1", Destination: "10.243.2.0/24", Code: "UnauthorizedOperation", Err: unauthorized}
+		Expect(deleteErr.Reason()).To(Equal(updater.ReasonPermissionDenied))
+		Expect(updater.OnlyDestinationErrors(multierr.Combine(nodeRouteErr, deleteErr))).To(BeFalse())
+		Expect(updater.OnlyDestinationErrors(nil)).To(BeFalse())
+	})
+
+	It("should describe route errors without the request ID", func() {
+		err := &smithy.OperationError{ServiceID: "EC2", OperationName: "CreateRoute", Err: &awshttp.ResponseError{
+			ResponseError: &smithyhttp.ResponseError{
+				Response: &smithyhttp.Response{Response: &http.Response{StatusCode: http.StatusBadRequest}},
+				Err:      &smithy.GenericAPIError{Code: "RouteLimitExceeded", Message: "The maximum number of routes has been reached."},
+			},
+			RequestID: "req-1",
+		}}
+		routeErr := &updater.RouteError{Operation: updater.RouteOperationCreate, TableID: "rt1", Destination: "10.243.0.0/24", InstanceID: "i-1", Code: "RouteLimitExceeded", Err: err}
+		Expect(routeErr.Error()).To(ContainSubstring("req-1"))
+		Expect(routeErr.Message()).To(Equal("creating route 10.243.0.0/24 -> i-1 in table rt1 failed: RouteLimitExceeded: The maximum number of routes has been reached."))
+
+		timeoutErr := &updater.RouteError{Operation: updater.RouteOperationDelete, TableID: "rt1", Destination: "10.243.0.0/24", Err: context.DeadlineExceeded}
+		Expect(timeoutErr.Message()).To(Equal("deleting route 10.243.0.0/24 in table rt1 failed: context deadline exceeded"))
+	})
+})
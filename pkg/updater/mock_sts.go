@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gardener/aws-custom-route-controller/pkg/updater (interfaces: CallerIdentity)
+
+// Package updater is a generated GoMock package.
+package updater
+
+import (
+	context "context"
+	reflect "reflect"
+
+	sts "github.com/aws/aws-sdk-go-v2/service/sts"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCallerIdentity is a mock of CallerIdentity interface.
+type MockCallerIdentity struct {
+	ctrl     *gomock.Controller
+	recorder *MockCallerIdentityMockRecorder
+}
+
+// MockCallerIdentityMockRecorder is the mock recorder for MockCallerIdentity.
+type MockCallerIdentityMockRecorder struct {
+	mock *MockCallerIdentity
+}
+
+// NewMockCallerIdentity creates a new mock instance.
+func NewMockCallerIdentity(ctrl *gomock.Controller) *MockCallerIdentity {
+	mock := &MockCallerIdentity{ctrl: ctrl}
+	mock.recorder = &MockCallerIdentityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCallerIdentity) EXPECT() *MockCallerIdentityMockRecorder {
+	return m.recorder
+}
+
+// GetCallerIdentity mocks base method.
+func (m *MockCallerIdentity) GetCallerIdentity(arg0 context.Context, arg1 *sts.GetCallerIdentityInput, arg2 ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCallerIdentity", varargs...)
+	ret0, _ := ret[0].(*sts.GetCallerIdentityOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCallerIdentity indicates an expected call of GetCallerIdentity.
+func (mr *MockCallerIdentityMockRecorder) GetCallerIdentity(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCallerIdentity", reflect.TypeOf((*MockCallerIdentity)(nil).GetCallerIdentity), varargs...)
+}
@@ -0,0 +1,165 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package updater
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// maxFilterValues is the maximum number of values of an EC2 describe filter
+const maxFilterValues = 200
+
+// tablePlan is the desired state of the managed routes of a route table
+type tablePlan struct {
+	// nodeRoutes are the node routes which should exist in the route table
+	nodeRoutes []NodeRoute
+	// peeringRoutes are the routes for the pod CIDRs of nodes in peered VPCs to the VPC peering connections
+	peeringRoutes []internalNodeRoute
+}
+
+// vpcTopology describes the VPCs of the nodes and the peering connections between VPCs
+type vpcTopology struct {
+	// instanceVPCs maps instance IDs to VPC IDs
+	instanceVPCs map[string]string
+	// peerings maps pairs of VPC IDs to the ID of an active peering connection
+	peerings map[vpcPair]string
+}
+
+type vpcPair struct {
+	a, b string
+}
+
+func newVPCPair(vpc1, vpc2 string) vpcPair {
+	if vpc1 > vpc2 {
+		vpc1, vpc2 = vpc2, vpc1
+	}
+	return vpcPair{a: vpc1, b: vpc2}
+}
+
+// SetMultiVPC enables managing route tables across several VPCs. Node routes are only created in route tables of
+// the node's VPC. Route tables of other VPCs get a route per node for its pod CIDR to the VPC peering connection with
+// the node's VPC. A single route per pod network is not possible, as its nodes may be spread over several VPCs.
+func (r *CustomRoutes) SetMultiVPC(enabled bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.multiVPC = enabled
+}
+
+// planRouteTables calculates the desired managed routes of each route table. In multi-VPC mode it also returns the
+// failures of node routes which cannot be planned for all route tables, i.e. if the VPC of the node instance is not
+// found or there is no active VPC peering connection to the VPC of a route table.
+func (r *CustomRoutes) planRouteTables(ctx context.Context, tables []ec2types.RouteTable, routes []NodeRoute) ([]tablePlan, map[string]*RouteFailure, error) {
+	plans := make([]tablePlan, len(tables))
+	if !r.multiVPC {
+		for i := range plans {
+			plans[i].nodeRoutes = routes
+		}
+		return plans, nil, nil
+	}
+
+	topology, err := r.describeVPCTopology(ctx, routes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	failures := map[string]*RouteFailure{}
+	for _, route := range routes {
+		if _, ok := topology.instanceVPCs[route.InstanceID]; !ok {
+			failures[route.PodCIDR] = &RouteFailure{
+				Reason:  ReasonInstanceNotFound,
+				Message: fmt.Sprintf("VPC of instance %s not found", route.InstanceID),
+			}
+		}
+	}
+	for i, table := range tables {
+		vpcID := aws.ToString(table.VpcId)
+		for _, route := range routes {
+			nodeVPC, ok := topology.instanceVPCs[route.InstanceID]
+			if !ok {
+				continue
+			}
+			if nodeVPC == vpcID {
+				plans[i].nodeRoutes = append(plans[i].nodeRoutes, route)
+				continue
+			}
+			id, ok := topology.peerings[newVPCPair(vpcID, nodeVPC)]
+			if !ok {
+				if _, exists := failures[route.PodCIDR]; !exists {
+					failures[route.PodCIDR] = &RouteFailure{
+						Reason: ReasonVPCPeeringMissing,
+						Message: fmt.Sprintf("no active VPC peering connection between VPC %s of route table %s and VPC %s of instance %s",
+							vpcID, aws.ToString(table.RouteTableId), nodeVPC, route.InstanceID),
+					}
+				}
+				continue
+			}
+			plans[i].peeringRoutes = append(plans[i].peeringRoutes, internalNodeRoute{
+				destinationCidrBlock:   route.PodCIDR,
+				instanceId:             route.InstanceID,
+				vpcPeeringConnectionId: id,
+			})
+		}
+	}
+	return plans, failures, nil
+}
+
+// describeVPCTopology looks up the VPCs of the node instances and the active VPC peering connections
+func (r *CustomRoutes) describeVPCTopology(ctx context.Context, routes []NodeRoute) (*vpcTopology, error) {
+	topology := &vpcTopology{
+		instanceVPCs: map[string]string{},
+		peerings:     map[vpcPair]string{},
+	}
+
+	instanceIDs := make([]string, 0, len(routes))
+	for _, route := range routes {
+		instanceIDs = append(instanceIDs, route.InstanceID)
+	}
+	for start := 0; start < len(instanceIDs); start += maxFilterValues {
+		end := min(start+maxFilterValues, len(instanceIDs))
+		paginator := ec2.NewDescribeInstancesPaginator(r.ec2, &ec2.DescribeInstancesInput{
+			Filters: []ec2types.Filter{{Name: aws.String("instance-id"), Values: instanceIDs[start:end]}},
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("describing instances failed: %w", err)
+			}
+			for _, reservation := range page.Reservations {
+				for _, instance := range reservation.Instances {
+					if instance.InstanceId != nil && instance.VpcId != nil {
+						topology.instanceVPCs[*instance.InstanceId] = *instance.VpcId
+					}
+				}
+			}
+		}
+	}
+
+	paginator := ec2.NewDescribeVpcPeeringConnectionsPaginator(r.ec2, &ec2.DescribeVpcPeeringConnectionsInput{
+		Filters: []ec2types.Filter{{Name: aws.String("status-code"), Values: []string{string(ec2types.VpcPeeringConnectionStateReasonCodeActive)}}},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing VPC peering connections failed: %w", err)
+		}
+		for _, peering := range page.VpcPeeringConnections {
+			if peering.VpcPeeringConnectionId == nil || peering.RequesterVpcInfo == nil || peering.AccepterVpcInfo == nil {
+				continue
+			}
+			pair := newVPCPair(aws.ToString(peering.RequesterVpcInfo.VpcId), aws.ToString(peering.AccepterVpcInfo.VpcId))
+			if _, ok := topology.peerings[pair]; !ok {
+				topology.peerings[pair] = *peering.VpcPeeringConnectionId
+			}
+		}
+	}
+	return topology, nil
+}
@@ -10,8 +10,11 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"reflect"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -20,8 +23,8 @@ import (
 
 // NodeRoute stores node internal IP and the pod CIDRs
 type NodeRoute struct {
-	InstanceID string
-	PodCIDR    string
+	InstanceID string `json:"instanceID"`
+	PodCIDR    string `json:"podCIDR"`
 }
 
 func NewNodeRoute(instanceID, podCIDR string) *NodeRoute {
@@ -48,15 +51,51 @@ func (r NodeRoute) Equals(other *NodeRoute) bool {
 
 type NodeRoutesUpdater func(ctx context.Context, routes []NodeRoute, tick func()) (*RouteUpdateResult, error)
 
+const (
+	// ReasonPodCIDROutOfRange is the conflict reason for a node pod CIDR outside of the pod networks
+	ReasonPodCIDROutOfRange = "PodCIDROutOfRange"
+	// ReasonPodCIDROverlap is the conflict reason for a node pod CIDR overlapping the pod CIDR of another node
+	ReasonPodCIDROverlap = "PodCIDROverlap"
+)
+
+// RouteConflict describes a node route excluded from routing because of an invalid pod CIDR
+type RouteConflict struct {
+	NodeName string    `json:"nodeName"`
+	Route    NodeRoute `json:"route"`
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message"`
+}
+
 type NamedNodeRoutes struct {
 	sync.Mutex
-	routes  map[string]NodeRoute
-	changed bool
+	routes      map[string]NodeRoute
+	changed     bool
+	podNetworks []net.IPNet
+	conflicts   []RouteConflict
+	// conflictsChanged is set if the conflicts changed since the last call of GetConflictsIfChanged
+	conflictsChanged bool
+	// created holds the creation time of the nodes to resolve overlapping pod CIDRs in favour of the oldest node
+	created map[string]time.Time
+	// accepted holds the routes of the last validation, which keep their pod CIDR against overlapping newcomers
+	accepted map[string]NodeRoute
+
+	// trackTimeToRoute enables recording the first appearance of pod CIDRs in pending
+	trackTimeToRoute bool
+	pending          map[string]pendingRoute
+}
+
+// pendingRoute is a node pod CIDR whose routes are not confirmed yet
+type pendingRoute struct {
+	podCIDR   string
+	firstSeen time.Time
 }
 
 func NewNamedNodeRoutes() *NamedNodeRoutes {
 	return &NamedNodeRoutes{
-		routes: map[string]NodeRoute{},
+		routes:   map[string]NodeRoute{},
+		pending:  map[string]pendingRoute{},
+		created:  map[string]time.Time{},
+		accepted: map[string]NodeRoute{},
 	}
 }
 
@@ -69,8 +108,12 @@ func (r *NamedNodeRoutes) AddNodeRoute(node *corev1.Node) (*NodeRoute, bool) {
 	r.Lock()
 	defer r.Unlock()
 
+	r.created[node.Name] = node.CreationTimestamp.Time
 	changed := false
-	if !r.routes[node.Name].Equals(route) {
+	if old, ok := r.routes[node.Name]; !old.Equals(route) {
+		if r.trackTimeToRoute && (!ok || old.PodCIDR != route.PodCIDR) {
+			r.pending[node.Name] = pendingRoute{podCIDR: route.PodCIDR, firstSeen: time.Now()}
+		}
 		r.routes[node.Name] = *route
 		changed = true
 		r.changed = true
@@ -82,6 +125,9 @@ func (r *NamedNodeRoutes) RemoveNodeRoute(nodeName string) *NodeRoute {
 	r.Lock()
 	defer r.Unlock()
 
+	delete(r.pending, nodeName)
+	delete(r.created, nodeName)
+	delete(r.accepted, nodeName)
 	if nr, ok := r.routes[nodeName]; ok {
 		delete(r.routes, nodeName)
 		r.changed = true
@@ -91,20 +137,155 @@ func (r *NamedNodeRoutes) RemoveNodeRoute(nodeName string) *NodeRoute {
 	return nil
 }
 
+// SetPodNetworks sets the pod networks all node pod CIDRs must be contained in
+func (r *NamedNodeRoutes) SetPodNetworks(podNetworkCIDRs []string) error {
+	podNetworks, err := parseNetworks(podNetworkCIDRs)
+	if err != nil {
+		return err
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	r.podNetworks = podNetworks
+	r.changed = true
+	return nil
+}
+
+// GetRoutesIfChanged returns all valid routes if there was any change since the last call.
+// Routes with conflicting pod CIDRs are excluded and can be retrieved with GetConflicts.
 func (r *NamedNodeRoutes) GetRoutesIfChanged() []NodeRoute {
 	r.Lock()
 	defer r.Unlock()
 	if !r.changed {
 		return nil
 	}
-	var routes []NodeRoute
-	for _, route := range r.routes {
-		routes = append(routes, route)
+	routes, conflicts := r.validate()
+	if !reflect.DeepEqual(conflicts, r.conflicts) {
+		r.conflictsChanged = true
 	}
+	r.conflicts = conflicts
 	r.changed = false
 	return routes
 }
 
+// Len returns the number of node routes including invalid ones
+func (r *NamedNodeRoutes) Len() int {
+	r.Lock()
+	defer r.Unlock()
+	return len(r.routes)
+}
+
+// GetConflicts returns the conflicts found on the last call of GetRoutesIfChanged
+func (r *NamedNodeRoutes) GetConflicts() []RouteConflict {
+	r.Lock()
+	defer r.Unlock()
+	return r.conflicts
+}
+
+// GetConflictsIfChanged returns the conflicts found on the last call of GetRoutesIfChanged and true if they changed
+// since the last call.
+func (r *NamedNodeRoutes) GetConflictsIfChanged() ([]RouteConflict, bool) {
+	r.Lock()
+	defer r.Unlock()
+	changed := r.conflictsChanged
+	r.conflictsChanged = false
+	return r.conflicts, changed
+}
+
+// validate checks all routes against the pod networks and against each other.
+// Of overlapping pod CIDRs, the first claimant keeps its route: a route accepted on the last validation wins,
+// otherwise the route of the oldest node. Only the newcomers are excluded.
+func (r *NamedNodeRoutes) validate() ([]NodeRoute, []RouteConflict) {
+	type namedRoute struct {
+		name     string
+		route    NodeRoute
+		ipnet    *net.IPNet
+		accepted bool
+		created  time.Time
+	}
+
+	var (
+		candidates []namedRoute
+		conflicts  []RouteConflict
+	)
+	names := make([]string, 0, len(r.routes))
+	for name := range r.routes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		route := r.routes[name]
+		_, ipnet, err := net.ParseCIDR(route.PodCIDR)
+		if err != nil {
+			continue
+		}
+		if len(r.podNetworks) > 0 && !r.inPodNetworks(ipnet) {
+			conflicts = append(conflicts, RouteConflict{
+				NodeName: name,
+				Route:    route,
+				Reason:   ReasonPodCIDROutOfRange,
+				Message:  fmt.Sprintf("pod CIDR %s is not contained in the pod network", route.PodCIDR),
+			})
+			continue
+		}
+		accepted, ok := r.accepted[name]
+		candidates = append(candidates, namedRoute{
+			name:     name,
+			route:    route,
+			ipnet:    ipnet,
+			accepted: ok && accepted == route,
+			created:  r.created[name],
+		})
+	}
+
+	claimants := make([]namedRoute, len(candidates))
+	copy(claimants, candidates)
+	sort.SliceStable(claimants, func(i, j int) bool {
+		if claimants[i].accepted != claimants[j].accepted {
+			return claimants[i].accepted
+		}
+		return claimants[i].created.Before(claimants[j].created)
+	})
+	var claimed []namedRoute
+	overlapping := map[string]string{}
+outer:
+	for _, claimant := range claimants {
+		for _, owner := range claimed {
+			if util.NetworksOverlap(claimant.ipnet, owner.ipnet) {
+				overlapping[claimant.name] = fmt.Sprintf("pod CIDR %s overlaps pod CIDR %s of node %s", claimant.route.PodCIDR, owner.route.PodCIDR, owner.name)
+				continue outer
+			}
+		}
+		claimed = append(claimed, claimant)
+	}
+
+	var routes []NodeRoute
+	r.accepted = map[string]NodeRoute{}
+	for _, candidate := range candidates {
+		if message, ok := overlapping[candidate.name]; ok {
+			conflicts = append(conflicts, RouteConflict{
+				NodeName: candidate.name,
+				Route:    candidate.route,
+				Reason:   ReasonPodCIDROverlap,
+				Message:  message,
+			})
+			continue
+		}
+		r.accepted[candidate.name] = candidate.route
+		routes = append(routes, candidate.route)
+	}
+	return routes, conflicts
+}
+
+func (r *NamedNodeRoutes) inPodNetworks(ipnet *net.IPNet) bool {
+	for i := range r.podNetworks {
+		if util.NetworkContains(&r.podNetworks[i], ipnet) {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *NamedNodeRoutes) SetChanged() {
 	r.Lock()
 	defer r.Unlock()
@@ -121,6 +302,15 @@ func extractNodeRoute(node *corev1.Node) *NodeRoute {
 	return NewNodeRoute(instanceID, podCIDR)
 }
 
+// InstanceID returns the EC2 instance ID from the provider ID of the node or an empty string
+func InstanceID(node *corev1.Node) string {
+	_, instanceID, err := decodeRegionAndInstanceID(node.Spec.ProviderID)
+	if err != nil {
+		return ""
+	}
+	return instanceID
+}
+
 // decodeRegionAndInstanceID extracts region and instanceID
 func decodeRegionAndInstanceID(providerID string) (string, string, error) {
 	if !strings.HasPrefix(providerID, "aws:") {
@@ -134,3 +324,17 @@ func decodeRegionAndInstanceID(providerID string) (string, string, error) {
 	}
 	return splitProviderID[len(splitProviderID)-2], splitProviderID[len(splitProviderID)-1], nil
 }
+
+// NodeRoutesFromNodes builds the valid node routes and the conflicts of the given nodes
+// the same way the node reconciler does.
+func NodeRoutesFromNodes(nodes []corev1.Node, podNetworkCIDRs []string) ([]NodeRoute, []RouteConflict, error) {
+	namedRoutes := NewNamedNodeRoutes()
+	if err := namedRoutes.SetPodNetworks(podNetworkCIDRs); err != nil {
+		return nil, nil, err
+	}
+	for i := range nodes {
+		namedRoutes.AddNodeRoute(&nodes[i])
+	}
+	routes := namedRoutes.GetRoutesIfChanged()
+	return routes, namedRoutes.GetConflicts(), nil
+}
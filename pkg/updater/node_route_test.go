@@ -5,6 +5,8 @@
 package updater_test
 
 import (
+	"time"
+
 	"github.com/gardener/aws-custom-route-controller/pkg/updater"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -83,6 +85,97 @@ var _ = Describe("NamedNodeRoutes", func() {
 		routes2 := routes.GetRoutesIfChanged()
 		Expect(len(routes2)).To(Equal(1))
 	})
+
+	It("should exclude conflicting routes", func() {
+		makeNode := func(name, podCIDR, instanceID string) *corev1.Node {
+			return &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: corev1.NodeSpec{
+					PodCIDRs:   []string{podCIDR},
+					ProviderID: makeProviderID(instanceID),
+				},
+			}
+		}
+
+		routes := updater.NewNamedNodeRoutes()
+		Expect(routes.SetPodNetworks([]string{"10.0.0.0/16"})).To(Succeed())
+		routes.AddNodeRoute(node1)
+		routes.AddNodeRoute(node2)
+		routes.AddNodeRoute(makeNode("overlapping", "10.0.0.0/22", "i-0003"))
+		routes.AddNodeRoute(makeNode("outside", "10.1.0.0/24", "i-0004"))
+
+		valid := routes.GetRoutesIfChanged()
+		Expect(valid).To(ConsistOf(*updater.NewNodeRoute(node1InstanceID, podCIDRs1[0]), *updater.NewNodeRoute(node2InstanceID, podCIDRs2[0])))
+		conflicts, changed := routes.GetConflictsIfChanged()
+		Expect(changed).To(BeTrue())
+		Expect(conflicts).To(HaveLen(2))
+		reasons := map[string]string{}
+		for _, conflict := range conflicts {
+			reasons[conflict.NodeName] = conflict.Reason
+		}
+		Expect(reasons).To(Equal(map[string]string{
+			"overlapping": updater.ReasonPodCIDROverlap,
+			"outside":     updater.ReasonPodCIDROutOfRange,
+		}))
+
+		routes.RemoveNodeRoute("overlapping")
+		Expect(routes.GetRoutesIfChanged()).To(HaveLen(2))
+		conflicts, changed = routes.GetConflictsIfChanged()
+		Expect(changed).To(BeTrue())
+		Expect(conflicts).To(HaveLen(1))
+		_, changed = routes.GetConflictsIfChanged()
+		Expect(changed).To(BeFalse())
+	})
+
+	It("should keep the pod CIDR of the first claimant", func() {
+		makeNode := func(name, podCIDR, instanceID string, created time.Time) *corev1.Node {
+			return &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)},
+				Spec: corev1.NodeSpec{
+					PodCIDRs:   []string{podCIDR},
+					ProviderID: makeProviderID(instanceID),
+				},
+			}
+		}
+		now := time.Now()
+
+		routes := updater.NewNamedNodeRoutes()
+		routes.AddNodeRoute(makeNode("b-new", "10.0.1.0/24", "i-0001", now))
+		routes.AddNodeRoute(makeNode("c-old", "10.0.1.0/24", "i-0002", now.Add(-time.Hour)))
+		Expect(routes.GetRoutesIfChanged()).To(Equal([]updater.NodeRoute{{InstanceID: "i-0002", PodCIDR: "10.0.1.0/24"}}))
+		Expect(routes.GetConflicts()).To(ConsistOf(HaveField("NodeName", "b-new")))
+
+		// an existing route wins against an older node
+		routes.AddNodeRoute(makeNode("a-oldest", "10.0.0.0/23", "i-0003", now.Add(-2*time.Hour)))
+		Expect(routes.GetRoutesIfChanged()).To(Equal([]updater.NodeRoute{{InstanceID: "i-0002", PodCIDR: "10.0.1.0/24"}}))
+		Expect(routes.GetConflicts()).To(ConsistOf(HaveField("NodeName", "a-oldest"), HaveField("NodeName", "b-new")))
+	})
+
+	It("should measure the time to route of pod CIDRs added after the start", func() {
+		routes := updater.NewNamedNodeRoutes()
+		routes.AddNodeRoute(node1)
+		routes.StartTimeToRoute()
+		routes.AddNodeRoute(node2)
+
+		start := time.Now()
+		result := &updater.RouteUpdateResult{
+			SuccessfulRoutes: map[string]bool{podCIDRs1[0]: true, podCIDRs2[0]: false},
+			Failures:         map[string]*updater.RouteFailure{},
+		}
+		Expect(routes.ConfirmRoutes(result, start)).To(BeEmpty())
+
+		result.SuccessfulRoutes[podCIDRs2[0]] = true
+		result.Failures[podCIDRs2[0]] = &updater.RouteFailure{Reason: updater.ReasonThrottled}
+		Expect(routes.ConfirmRoutes(result, start)).To(BeEmpty())
+
+		delete(result.Failures, podCIDRs2[0])
+		confirmed := routes.ConfirmRoutes(result, start.Add(time.Minute))
+		Expect(confirmed).To(HaveLen(1))
+		Expect(confirmed[0].NodeName).To(Equal(node2.Name))
+		Expect(confirmed[0].PodCIDR).To(Equal(podCIDRs2[0]))
+		Expect(confirmed[0].Duration).To(BeNumerically("~", time.Minute, time.Second))
+		Expect(routes.ConfirmRoutes(result, start.Add(2*time.Minute))).To(BeEmpty())
+	})
 })
 
 func makeProviderID(instanceID string) string {
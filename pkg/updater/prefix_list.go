@@ -0,0 +1,199 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package updater
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+const (
+	// PrefixListContentNodePodCIDRs exports the pod CIDRs of all nodes to the managed prefix list.
+	PrefixListContentNodePodCIDRs = "node-pod-cidrs"
+	// PrefixListContentPodNetworks exports the pod network CIDRs to the managed prefix list.
+	PrefixListContentPodNetworks = "pod-networks"
+
+	// maxPrefixListChanges is the maximum number of entries added or removed by a single ModifyManagedPrefixList call
+	maxPrefixListChanges = 100
+	// prefixListSizeStep is the granularity of the maximum number of entries of a resized prefix list, so that not
+	// every new node needs another resize
+	prefixListSizeStep = 20
+	// prefixListRequeueDelay is the delay of the next update if prefix list changes are still pending
+	prefixListRequeueDelay = 15 * time.Second
+	// podNetworkEntryDescription is the description of prefix list entries for pod networks
+	podNetworkEntryDescription = "pod network"
+)
+
+// AllPrefixListContents is a slice of all available managed prefix list contents.
+var AllPrefixListContents = []string{
+	PrefixListContentNodePodCIDRs,
+	PrefixListContentPodNetworks,
+}
+
+// SetPrefixList enables keeping the entries of the given managed prefix list in sync with the node pod CIDRs
+// or the pod networks. The prefix list is owned by the controller, i.e. all other entries are removed.
+func (r *CustomRoutes) SetPrefixList(prefixListID, content string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.prefixListID = prefixListID
+	r.prefixListContent = content
+}
+
+// updatePrefixList adds missing and removes stale entries of the managed prefix list.
+// If the prefix list is too small, it is resized first and the entries are modified on a later update. It returns true
+// if changes are still pending, e.g. because of a resize, because another modification of the prefix list is in
+// progress or because more entries changed than a single call can modify.
+func (r *CustomRoutes) updatePrefixList(ctx context.Context, routes []NodeRoute, tick func()) (bool, error) {
+	if r.prefixListID == "" {
+		return false, nil
+	}
+
+	tick()
+	prefixList, err := r.describePrefixList(ctx)
+	if err != nil {
+		return false, err
+	}
+	if isPrefixListModifying(prefixList) {
+		r.log.Info("managed prefix list is being modified, requeueing", "prefixList", r.prefixListID, "state", prefixList.State)
+		return true, nil
+	}
+
+	actual := map[string]bool{}
+	paginator := ec2.NewGetManagedPrefixListEntriesPaginator(r.ec2, &ec2.GetManagedPrefixListEntriesInput{
+		PrefixListId:  aws.String(r.prefixListID),
+		TargetVersion: prefixList.Version,
+	})
+	for paginator.HasMorePages() {
+		tick()
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return false, fmt.Errorf("getting entries of managed prefix list %s failed: %w", r.prefixListID, err)
+		}
+		for _, entry := range page.Entries {
+			if entry.Cidr != nil {
+				actual[*entry.Cidr] = true
+			}
+		}
+	}
+
+	desired := r.desiredPrefixListEntries(routes)
+	var toBeAdded []ec2types.AddPrefixListEntry
+	for _, entry := range desired {
+		if !actual[*entry.Cidr] {
+			toBeAdded = append(toBeAdded, entry)
+		}
+		delete(actual, *entry.Cidr)
+	}
+	var toBeRemoved []ec2types.RemovePrefixListEntry
+	for _, cidr := range sortedKeys(actual) {
+		toBeRemoved = append(toBeRemoved, ec2types.RemovePrefixListEntry{Cidr: aws.String(cidr)})
+	}
+	if len(toBeAdded) == 0 && len(toBeRemoved) == 0 {
+		return false, nil
+	}
+
+	// the size of a prefix list cannot be changed together with its entries
+	if maxEntries := aws.ToInt32(prefixList.MaxEntries); int32(len(desired)) > maxEntries {
+		size := prefixListSize(len(desired))
+		tick()
+		_, err := r.ec2.ModifyManagedPrefixList(ctx, &ec2.ModifyManagedPrefixListInput{
+			PrefixListId: aws.String(r.prefixListID),
+			MaxEntries:   aws.Int32(size),
+		})
+		if err != nil {
+			return false, fmt.Errorf("resizing managed prefix list %s from %d to %d entries failed: %w", r.prefixListID, maxEntries, size, err)
+		}
+		r.log.Info("managed prefix list resized, requeueing", "prefixList", r.prefixListID, "maxEntries", size)
+		return true, nil
+	}
+
+	added, removed := toBeAdded[:min(len(toBeAdded), maxPrefixListChanges)], toBeRemoved[:min(len(toBeRemoved), maxPrefixListChanges)]
+	tick()
+	_, err = r.ec2.ModifyManagedPrefixList(ctx, &ec2.ModifyManagedPrefixListInput{
+		PrefixListId:   aws.String(r.prefixListID),
+		CurrentVersion: prefixList.Version,
+		AddEntries:     added,
+		RemoveEntries:  removed,
+	})
+	if err != nil {
+		return false, fmt.Errorf("modifying managed prefix list %s at version %d failed: %w", r.prefixListID, aws.ToInt64(prefixList.Version), err)
+	}
+	r.log.Info("managed prefix list modified", "prefixList", r.prefixListID, "version", aws.ToInt64(prefixList.Version), "added", len(added), "removed", len(removed))
+	if pending := len(toBeAdded) - len(added) + len(toBeRemoved) - len(removed); pending > 0 {
+		r.log.Info("managed prefix list changes pending, requeueing", "prefixList", r.prefixListID, "pending", pending)
+		return true, nil
+	}
+	return false, nil
+}
+
+// describePrefixList returns the managed prefix list
+func (r *CustomRoutes) describePrefixList(ctx context.Context) (*ec2types.ManagedPrefixList, error) {
+	response, err := r.ec2.DescribeManagedPrefixLists(ctx, &ec2.DescribeManagedPrefixListsInput{
+		PrefixListIds: []string{r.prefixListID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing managed prefix list %s failed: %w", r.prefixListID, err)
+	}
+	if len(response.PrefixLists) == 0 {
+		return nil, fmt.Errorf("managed prefix list %s not found", r.prefixListID)
+	}
+	return &response.PrefixLists[0], nil
+}
+
+func isPrefixListModifying(prefixList *ec2types.ManagedPrefixList) bool {
+	return strings.HasSuffix(string(prefixList.State), "-in-progress")
+}
+
+// prefixListSize returns the maximum number of entries for the given number of entries rounded up to the next step
+func prefixListSize(entries int) int32 {
+	return int32((entries + prefixListSizeStep - 1) / prefixListSizeStep * prefixListSizeStep)
+}
+
+// desiredPrefixListEntries returns the sorted entries of the managed prefix list
+func (r *CustomRoutes) desiredPrefixListEntries(routes []NodeRoute) []ec2types.AddPrefixListEntry {
+	var entries []ec2types.AddPrefixListEntry
+	if r.prefixListContent == PrefixListContentPodNetworks {
+		for _, podNetwork := range r.podNetworks {
+			entries = append(entries, ec2types.AddPrefixListEntry{
+				Cidr:        aws.String(podNetwork.String()),
+				Description: aws.String(podNetworkEntryDescription),
+			})
+		}
+	} else {
+		for _, route := range routes {
+			_, ipnet, err := net.ParseCIDR(route.PodCIDR)
+			if err != nil || ipnet.IP.To4() == nil {
+				continue
+			}
+			entries = append(entries, ec2types.AddPrefixListEntry{
+				Cidr:        aws.String(ipnet.String()),
+				Description: aws.String(route.InstanceID),
+			})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return *entries[i].Cidr < *entries[j].Cidr
+	})
+	return entries
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
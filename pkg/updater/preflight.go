@@ -0,0 +1,141 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package updater
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+const (
+	// errorCodeDryRunOperation is returned by EC2 for a dry run request which would have succeeded
+	errorCodeDryRunOperation = "DryRunOperation"
+	// errorCodeUnauthorizedOperation is returned by EC2 if the caller is not allowed to perform the operation
+	errorCodeUnauthorizedOperation = "UnauthorizedOperation"
+	// errorCodeAuthFailure is returned by EC2 if the credentials are invalid
+	errorCodeAuthFailure = "AuthFailure"
+
+	// preflightInstanceID is a well-formed instance ID used for dry run requests needing a route target
+	preflightInstanceID = "i-00000000000000000"
+)
+
+// PreflightCheck is the result of a single preflight check
+type PreflightCheck struct {
+	// Name is the name of the check
+	Name string
+	// Message describes the result of the check
+	Message string
+	// Err is set if the check failed
+	Err error
+}
+
+// PreflightResult contains the results of all preflight checks
+type PreflightResult struct {
+	Checks []PreflightCheck
+}
+
+// Err returns an error listing all failed checks or nil
+func (r *PreflightResult) Err() error {
+	var failed []string
+	for _, check := range r.Checks {
+		if check.Err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", check.Name, check.Err))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("preflight checks failed: %s", strings.Join(failed, "; "))
+}
+
+func (r *PreflightResult) add(name, message string, err error) {
+	r.Checks = append(r.Checks, PreflightCheck{Name: name, Message: message, Err: err})
+}
+
+// Preflight checks the AWS caller identity, the permissions of the EC2 operations and
+// that the cluster has at least one tagged route table.
+// The permissions are checked with the EC2 DryRun flag, i.e. nothing is changed.
+func (r *CustomRoutes) Preflight(ctx context.Context) *PreflightResult {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	result := &PreflightResult{}
+
+	if r.callerIdentity != nil {
+		identity, err := r.callerIdentity.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			result.add("CallerIdentity", "", err)
+		} else {
+			result.add("CallerIdentity", aws.ToString(identity.Arn), nil)
+		}
+	}
+
+	result.add(dryRunCheck("DescribeRouteTables", func() error {
+		_, err := r.ec2.DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{DryRun: aws.Bool(true)})
+		return err
+	}))
+	result.add(dryRunCheck("DescribeInstances", func() error {
+		_, err := r.ec2.DescribeInstances(ctx, &ec2.DescribeInstancesInput{DryRun: aws.Bool(true)})
+		return err
+	}))
+
+	tables, err := r.findRouteTables(ctx)
+	if err != nil {
+		result.add("RouteTables", "", err)
+		return result
+	}
+	var tableIDs []string
+	for _, table := range tables {
+		tableIDs = append(tableIDs, aws.ToString(table.RouteTableId))
+	}
+	result.add("RouteTables", fmt.Sprintf("found tagged route tables %s", strings.Join(tableIDs, ",")), nil)
+
+	destination := r.podNetworks[0].String()
+	result.add(dryRunCheck("CreateRoute", func() error {
+		_, err := r.ec2.CreateRoute(ctx, &ec2.CreateRouteInput{
+			DryRun:               aws.Bool(true),
+			DestinationCidrBlock: aws.String(destination),
+			InstanceId:           aws.String(preflightInstanceID),
+			RouteTableId:         tables[0].RouteTableId,
+		})
+		return err
+	}))
+	result.add(dryRunCheck("DeleteRoute", func() error {
+		_, err := r.ec2.DeleteRoute(ctx, &ec2.DeleteRouteInput{
+			DryRun:               aws.Bool(true),
+			DestinationCidrBlock: aws.String(destination),
+			RouteTableId:         tables[0].RouteTableId,
+		})
+		return err
+	}))
+
+	return result
+}
+
+// dryRunCheck interprets the error of a dry run request.
+// Other AWS API errors (e.g. a non-existing route) are inconclusive and not treated as failure.
+func dryRunCheck(name string, call func() error) (string, string, error) {
+	err := call()
+	if err == nil {
+		return name, "permitted", nil
+	}
+	switch code := AWSErrorCode(err); code {
+	case errorCodeDryRunOperation:
+		return name, "permitted", nil
+	case errorCodeUnauthorizedOperation:
+		return name, "", fmt.Errorf("not permitted: %w", err)
+	case "", errorCodeAuthFailure:
+		return name, "", err
+	default:
+		return name, fmt.Sprintf("inconclusive (%s)", code), nil
+	}
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package updater_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/gardener/aws-custom-route-controller/pkg/updater"
+)
+
+var _ = Describe("Preflight", func() {
+	var (
+		ctrl               *gomock.Controller
+		customRoutes       *updater.CustomRoutes
+		ec2RoutesMock      *updater.MockEC2Routes
+		callerIdentityMock *updater.MockCallerIdentity
+		ctx                = context.Background()
+		clusterName        = "shoot--foo--bar"
+		tables             = []ec2types.RouteTable{
+			{
+				RouteTableId: aws.String("rt1"),
+				Tags:         []ec2types.Tag{{Key: aws.String(updater.ClusterTagKey(clusterName)), Value: aws.String("1")}},
+			},
+		}
+		dryRunOK     = &smithy.GenericAPIError{Code: "DryRunOperation"}
+		unauthorized = &smithy.GenericAPIError{Code: "UnauthorizedOperation"}
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		ec2RoutesMock = updater.NewMockEC2Routes(ctrl)
+		callerIdentityMock = updater.NewMockCallerIdentity(ctrl)
+
+		var err error
+		customRoutes, err = updater.NewCustomRoutes(logf.Log.WithName("test"), ec2RoutesMock, clusterName, []string{"10.243.0.0/19"})
+		Expect(err).To(BeNil())
+		customRoutes.SetCallerIdentity(callerIdentityMock)
+
+		callerIdentityMock.EXPECT().GetCallerIdentity(ctx, gomock.Any()).Return(&sts.GetCallerIdentityOutput{Arn: aws.String("arn:aws:iam::123:user/foo")}, nil)
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{DryRun: aws.Bool(true)}).Return(nil, dryRunOK)
+		ec2RoutesMock.EXPECT().DescribeInstances(ctx, &ec2.DescribeInstancesInput{DryRun: aws.Bool(true)}).Return(nil, dryRunOK)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should pass if all operations are permitted", func() {
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables}, nil)
+		ec2RoutesMock.EXPECT().CreateRoute(ctx, gomock.Any()).Return(nil, dryRunOK)
+		ec2RoutesMock.EXPECT().DeleteRoute(ctx, gomock.Any()).Return(nil, dryRunOK)
+
+		result := customRoutes.Preflight(ctx)
+		Expect(result.Err()).To(BeNil())
+		Expect(result.Checks).To(HaveLen(6))
+		Expect(result.Checks[0].Message).To(Equal("arn:aws:iam::123:user/foo"))
+	})
+
+	It("should fail if an operation is not permitted", func() {
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables}, nil)
+		ec2RoutesMock.EXPECT().CreateRoute(ctx, gomock.Any()).Return(nil, unauthorized)
+		ec2RoutesMock.EXPECT().DeleteRoute(ctx, gomock.Any()).Return(nil, &smithy.GenericAPIError{Code: "InvalidRoute.NotFound"})
+
+		result := customRoutes.Preflight(ctx)
+		Expect(result.Err()).To(MatchError(ContainSubstring("CreateRoute: not permitted")))
+		Expect(result.Checks[5].Err).To(BeNil())
+		Expect(result.Checks[5].Message).To(ContainSubstring("inconclusive"))
+	})
+
+	It("should fail if no tagged route table exists", func() {
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+		result := customRoutes.Preflight(ctx)
+		Expect(result.Err()).To(MatchError(ContainSubstring("RouteTables")))
+		Expect(result.Checks).To(HaveLen(4))
+	})
+})
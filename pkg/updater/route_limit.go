@@ -0,0 +1,85 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package updater
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+const (
+	// DefaultRouteLimit is the default quota of routes per route table
+	DefaultRouteLimit = 50
+	// MaxRouteLimit is the maximum quota of routes per route table which can be requested
+	MaxRouteLimit = 1000
+	// RoutesPerRouteTableQuotaCode is the Service Quotas code of the Amazon VPC quota 'Routes per route table'
+	RoutesPerRouteTableQuotaCode = "L-93826ACB"
+	// ReasonRouteLimitExceeded is the failure reason for a node route not created because the route table is full
+	ReasonRouteLimitExceeded = "RouteLimitExceeded"
+)
+
+// RouteQuota provides the maximum number of routes per route table, e.g. by looking up the applied value of
+// RoutesPerRouteTableQuotaCode with Service Quotas. Implementations should cache the value, it is requested on
+// every update.
+type RouteQuota interface {
+	RouteLimit(ctx context.Context) (int, error)
+}
+
+// StaticRouteQuota is a RouteQuota with a fixed route limit, e.g. from the configuration
+type StaticRouteQuota int
+
+// RouteLimit returns the fixed route limit
+func (q StaticRouteQuota) RouteLimit(_ context.Context) (int, error) {
+	return int(q), nil
+}
+
+// SetRouteQuota sets the source of the route limit reported as RouteTableResult.RouteLimit.
+// If nil, the route limit is unknown.
+func (r *CustomRoutes) SetRouteQuota(quota RouteQuota) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.routeQuota = quota
+}
+
+// Headroom returns the number of routes which can still be created in the route table and false if the route limit
+// is unknown
+func (t RouteTableResult) Headroom() (int, bool) {
+	if t.RouteLimit <= 0 {
+		return 0, false
+	}
+	return max(t.RouteLimit-t.TotalRoutes, 0), true
+}
+
+// routeLimit returns the route limit of the route quota or zero if it is unknown
+func (r *CustomRoutes) routeLimit(ctx context.Context) int {
+	if r.routeQuota == nil {
+		return 0
+	}
+	limit, err := r.routeQuota.RouteLimit(ctx)
+	if err != nil {
+		r.log.Error(err, "looking up route limit failed")
+		return 0
+	}
+	return limit
+}
+
+// countQuotaRoutes returns the number of routes of the route table counting towards the route limit.
+// Propagated routes and the local routes of the VPC CIDRs, which are created with the route table, are not counted.
+// Blackhole routes are counted as they still occupy an entry.
+func countQuotaRoutes(table ec2types.RouteTable) int {
+	count := 0
+	for _, route := range table.Routes {
+		if route.Origin == ec2types.RouteOriginEnableVgwRoutePropagation || route.Origin == ec2types.RouteOriginCreateRouteTable ||
+			aws.ToString(route.GatewayId) == "local" {
+			continue
+		}
+		count++
+	}
+	return count
+}
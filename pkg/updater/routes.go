@@ -8,47 +8,219 @@ package updater
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"sort"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
 	"github.com/go-logr/logr"
 	"go.uber.org/multierr"
+
+	"github.com/gardener/aws-custom-route-controller/pkg/util"
+)
+
+const (
+	// ReasonPodCIDRProtected is the failure reason for a node pod CIDR overlapping a protected CIDR
+	ReasonPodCIDRProtected = "PodCIDRProtected"
+)
+
+// RouteOperation is the kind of change applied to a route in a route table
+type RouteOperation string
+
+const (
+	// RouteOperationCreate is the creation of a route
+	RouteOperationCreate RouteOperation = "Create"
+	// RouteOperationDelete is the deletion of a route
+	RouteOperationDelete RouteOperation = "Delete"
+	// RouteOperationReplace is the replacement of the target of a route
+	RouteOperationReplace RouteOperation = "Replace"
 )
 
 // RouteUpdateResult tracks the result of updating routes for each node
 type RouteUpdateResult struct {
-	SuccessfulRoutes map[string]bool // maps pod CIDR to success status
+	SuccessfulRoutes     map[string]bool          // maps pod CIDR to success status
+	Failures             map[string]*RouteFailure // maps pod CIDR to a specific failure reason
+	Outcomes             []RouteOutcome           // all route operations applied to the route tables
+	Tables               []RouteTableResult       // state of all route tables after the update
+	TransitGatewayTables []RouteTableResult       // state of all transit gateway route tables after the update
+	NextRetry            time.Time                // earliest retry of a failed node route in backoff or of pending changes, zero if none
+	Quarantined          []string                 // pod CIDRs of quarantined node routes
+}
+
+// RouteTableResult is the state of a route table after an update
+type RouteTableResult struct {
+	TableID string
+	VPCID   string
+	// DesiredRoutes is the number of node routes which should exist in the route table
+	DesiredRoutes int
+	// ActualRoutes is the number of node routes existing in the route table after the update
+	ActualRoutes int
+	// TotalRoutes is the number of all routes in the route table after the update counting towards the route limit.
+	// It is only counted if the route limit is known.
+	TotalRoutes int
+	// RouteLimit is the maximum number of routes in the route table or zero if unknown
+	RouteLimit int
+	// UnmanagedRoutes are routes in the pod network not managed by the controller
+	UnmanagedRoutes []UnmanagedRoute
+}
+
+// UnmanagedRoute is a route in the pod network not managed by the controller
+type UnmanagedRoute struct {
+	Destination string `json:"destination"`
+	Target      string `json:"target,omitempty"`
+	Origin      string `json:"origin,omitempty"`
+}
+
+// StatusReporter persists the result of an update, e.g. as Kubernetes objects
+type StatusReporter interface {
+	ReportStatus(ctx context.Context, result *RouteUpdateResult) error
+}
+
+// RouteOutcome is the result of a single route operation in a route table
+type RouteOutcome struct {
+	Operation   RouteOperation
+	TableID     string
+	Destination string
+	InstanceID  string
+	// VPCPeeringConnectionID is the target of a route for the pod CIDR of a node in a peered VPC
+	VPCPeeringConnectionID string
+	// TransitGatewayAttachmentID is the target of a pod network route in a transit gateway route table
+	TransitGatewayAttachmentID string
+	// Err is the error of the operation or nil if it succeeded
+	Err error
+}
+
+// ErrorCode returns the AWS error code of the operation or an empty string
+func (o RouteOutcome) ErrorCode() string {
+	return AWSErrorCode(o.Err)
+}
+
+// ErrorMessage returns the AWS error code and message of the operation without request ID or an empty string
+func (o RouteOutcome) ErrorMessage() string {
+	if o.Err == nil {
+		return ""
+	}
+	return awsErrorMessage(o.Err)
+}
+
+// AWSErrorCode returns the AWS API error code of the given error or an empty string
+func AWSErrorCode(err error) string {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode()
+	}
+	return ""
+}
+
+// RouteFailure describes why a route was not created if there is a more specific reason than a failed AWS call
+type RouteFailure struct {
+	Reason  string
+	Message string
 }
 
 // CustomRoutes updates route tables for an AWS cluster
 type CustomRoutes struct {
-	log         logr.Logger
-	ec2         EC2Routes
-	clusterName string
-	podNetwork  net.IPNet
+	log            logr.Logger
+	clusterName    string
+	statusReporter StatusReporter
+
+	// lock protects the AWS clients and the networks, which may be changed at runtime
+	lock              sync.RWMutex
+	ec2               EC2Routes
+	callerIdentity    CallerIdentity
+	podNetworks       []net.IPNet
+	protectedNetworks []net.IPNet
+	multiVPC          bool
+
+	transitGatewayRouteTableIDs []string
+	transitGatewayAttachmentID  string
+
+	prefixListID      string
+	prefixListContent string
+
+	securityGroupRules bool
+
+	routeQuota RouteQuota
+
+	backoff *routeBackoff
 }
 
-// NewCustomRoutes creates a new CustomRoutes instance
-func NewCustomRoutes(log logr.Logger, ec2Routes EC2Routes, clusterName, podNetworkCIDR string) (*CustomRoutes, error) {
-	_, ipnet, err := net.ParseCIDR(podNetworkCIDR)
-	if err != nil {
-		return nil, err
-	}
-	return &CustomRoutes{
+// NewCustomRoutes creates a new CustomRoutes instance managing routes for all given pod network CIDRs
+func NewCustomRoutes(log logr.Logger, ec2Routes EC2Routes, clusterName string, podNetworkCIDRs []string) (*CustomRoutes, error) {
+	r := &CustomRoutes{
 		log:         log,
 		ec2:         ec2Routes,
 		clusterName: clusterName,
-		podNetwork:  *ipnet,
-	}, nil
+		backoff:     newRouteBackoff(),
+	}
+	if err := r.SetPodNetworks(podNetworkCIDRs); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SetPodNetworks sets the managed pod networks. It may be called while updates are running.
+func (r *CustomRoutes) SetPodNetworks(podNetworkCIDRs []string) error {
+	if len(podNetworkCIDRs) == 0 {
+		return fmt.Errorf("at least one pod network CIDR is required")
+	}
+	podNetworks, err := parseNetworks(podNetworkCIDRs)
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.podNetworks = podNetworks
+	return nil
+}
+
+// SetProtectedCIDRs sets the destination CIDRs for which routes are never created or deleted.
+// It may be called while updates are running.
+func (r *CustomRoutes) SetProtectedCIDRs(protectedCIDRs []string) error {
+	protectedNetworks, err := parseNetworks(protectedCIDRs)
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.protectedNetworks = protectedNetworks
+	return nil
+}
+
+// SetStatusReporter sets the reporter called with the result of each update
+func (r *CustomRoutes) SetStatusReporter(reporter StatusReporter) {
+	r.statusReporter = reporter
+}
+
+// SetCallerIdentity sets the STS client used by the preflight checks
+func (r *CustomRoutes) SetCallerIdentity(callerIdentity CallerIdentity) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.callerIdentity = callerIdentity
+}
+
+// SetAWSClients replaces the AWS clients, e.g. after the credentials have changed.
+// It may be called while updates are running.
+func (r *CustomRoutes) SetAWSClients(ec2Routes EC2Routes, callerIdentity CallerIdentity) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.ec2 = ec2Routes
+	r.callerIdentity = callerIdentity
 }
 
 type internalNodeRoute struct {
-	destinationCidrBlock string
-	instanceId           string
+	destinationCidrBlock       string
+	instanceId                 string
+	vpcPeeringConnectionId     string
+	transitGatewayAttachmentId string
 }
 
 func (r *CustomRoutes) findRouteTables(ctx context.Context) ([]ec2types.RouteTable, error) {
@@ -83,7 +255,7 @@ func (r *CustomRoutes) getNetworkInterfaces(ctx context.Context, instanceID stri
 	}
 
 	if len(response.Reservations) == 0 || len(response.Reservations[0].Instances) == 0 {
-		return nil, fmt.Errorf("instance %s not found", instanceID)
+		return nil, fmt.Errorf("%w: %s", ErrInstanceNotFound, instanceID)
 	}
 
 	instance := response.Reservations[0].Instances[0]
@@ -115,14 +287,21 @@ func (r *CustomRoutes) getNetworkInterfaces(ctx context.Context, instanceID stri
 // Update updates all found route tables (tagged with the clusterName) with the podCIDR to node instance routes
 // Returns a RouteUpdateResult that tracks which routes were successfully created
 func (r *CustomRoutes) Update(ctx context.Context, routes []NodeRoute, tick func()) (*RouteUpdateResult, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
 	result := &RouteUpdateResult{
 		SuccessfulRoutes: make(map[string]bool),
+		Failures:         make(map[string]*RouteFailure),
 	}
 
 	// Initially mark all routes as not successful
 	for _, route := range routes {
 		result.SuccessfulRoutes[route.PodCIDR] = false
 	}
+	allRoutes := routes
+	routes = r.filterProtectedRoutes(routes, result)
+	r.backoff.retain(allRoutes)
 
 	tick()
 	tables, err := r.findRouteTables(ctx)
@@ -130,115 +309,351 @@ func (r *CustomRoutes) Update(ctx context.Context, routes []NodeRoute, tick func
 		return result, err
 	}
 
+	plans, planFailures, err := r.planRouteTables(ctx, tables, routes)
+	if err != nil {
+		return result, err
+	}
+
+	routeLimit := r.routeLimit(ctx)
 	var updateErrors error
-	for _, table := range tables {
+	now := time.Now()
+	// attempts holds the first error of each attempted destination or nil if all attempts succeeded
+	attempts := map[string]*RouteError{}
+	// a destination is successful if it is desired in any route table and exists in all of them
+	desired := map[string]bool{}
+	failed := map[string]bool{}
+	for destination, failure := range planFailures {
+		failed[destination] = true
+		result.Failures[destination] = failure
+	}
+	for i, table := range tables {
 		tick()
-		toBeCreated, toBeDeleted := r.calcRouteChanges(table, routes)
-
-		for _, del := range toBeDeleted {
-			req := &ec2.DeleteRouteInput{
-				RouteTableId:         table.RouteTableId,
-				DestinationCidrBlock: aws.String(del.destinationCidrBlock),
-			}
-			tick()
-			_, err = r.ec2.DeleteRoute(ctx, req)
-			if err != nil {
-				updateErrors = multierr.Append(updateErrors, fmt.Errorf("deleting route %s in table %s failed: %w", del.destinationCidrBlock, *table.RouteTableId, err))
-				continue
-			}
-			r.log.Info("route deleted", "table", *table.RouteTableId, "destination", del.destinationCidrBlock, "instanceId", del.instanceId)
+		toBeCreated, toBeDeleted := r.calcRouteChanges(table, plans[i])
+		tableResult := r.newRouteTableResult(table, plans[i])
+		if routeLimit > 0 {
+			tableResult.RouteLimit = routeLimit
+			tableResult.TotalRoutes = countQuotaRoutes(table)
 		}
 
+		updateErrors = multierr.Append(updateErrors, r.deleteRoutes(ctx, table, toBeDeleted, result, tick))
+
 		for _, create := range toBeCreated {
-			networkInterfaceIds, err := r.getNetworkInterfaces(ctx, create.instanceId)
-			if err != nil {
-				updateErrors = multierr.Append(updateErrors, fmt.Errorf("getting network interfaces for instance %s failed: %w", create.instanceId, err))
-				result.SuccessfulRoutes[create.destinationCidrBlock] = false
+			if blocked, failure := r.backoff.blocked(create, now); blocked {
+				failed[create.destinationCidrBlock] = true
+				result.Failures[create.destinationCidrBlock] = failure
 				continue
 			}
-
-			// Multi-NIC instances: AWS rejects InstanceId in CreateRoute, must use NetworkInterfaceId.
-			// Try each ENI (sorted by device index), first success wins.
-			if len(networkInterfaceIds) > 1 {
-				routeCreated := false
-				for _, eniId := range networkInterfaceIds {
-					req := &ec2.CreateRouteInput{
-						DestinationCidrBlock: aws.String(create.destinationCidrBlock),
-						NetworkInterfaceId:   aws.String(eniId),
-						RouteTableId:         table.RouteTableId,
-					}
-					tick()
-					_, err = r.ec2.CreateRoute(ctx, req)
-					if err == nil {
-						r.log.Info("route created", "table", *table.RouteTableId, "destination", create.destinationCidrBlock, "instanceId", create.instanceId, "networkInterfaceId", eniId)
-						routeCreated = true
-						break
-					}
-				}
-				if !routeCreated {
-					updateErrors = multierr.Append(updateErrors, fmt.Errorf("creating route %s -> %s in table %s failed on all ENIs", create.destinationCidrBlock, create.instanceId, *table.RouteTableId))
-				}
-				result.SuccessfulRoutes[create.destinationCidrBlock] = routeCreated
+			var routeErr *RouteError
+			if create.vpcPeeringConnectionId != "" {
+				routeErr = r.createPeeringRoute(ctx, table, create, result, tick)
 			} else {
-				// Single NIC: use InstanceId as before
-				req := &ec2.CreateRouteInput{
-					DestinationCidrBlock: aws.String(create.destinationCidrBlock),
-					InstanceId:           aws.String(create.instanceId),
-					RouteTableId:         table.RouteTableId,
-				}
-				tick()
-				_, err = r.ec2.CreateRoute(ctx, req)
-				if err != nil {
-					updateErrors = multierr.Append(updateErrors, fmt.Errorf("creating route %s -> %s in table %s failed: %w", create.destinationCidrBlock, create.instanceId, *table.RouteTableId, err))
-					result.SuccessfulRoutes[create.destinationCidrBlock] = false
-					continue
-				}
-				result.SuccessfulRoutes[create.destinationCidrBlock] = true
-				r.log.Info("route created", "table", *table.RouteTableId, "destination", create.destinationCidrBlock, "instanceId", create.instanceId)
+				routeErr = r.createNodeRoute(ctx, table, create, result, tick)
 			}
-		}
-
-		// Mark routes that already exist (not in toBeCreated) as successful
-		for _, route := range routes {
-			foundInToBeCreated := false
-			for _, create := range toBeCreated {
-				if create.destinationCidrBlock == route.PodCIDR {
-					foundInToBeCreated = true
-					break
-				}
+			if routeErr != nil {
+				failed[create.destinationCidrBlock] = true
 			}
-			if !foundInToBeCreated {
-				// Route already exists, mark as successful
-				result.SuccessfulRoutes[route.PodCIDR] = true
+			if attempt, ok := attempts[create.destinationCidrBlock]; !ok || attempt == nil {
+				attempts[create.destinationCidrBlock] = routeErr
 			}
 		}
 
+		for _, route := range plans[i].nodeRoutes {
+			desired[route.PodCIDR] = true
+		}
+		for _, route := range plans[i].peeringRoutes {
+			desired[route.destinationCidrBlock] = true
+		}
+
 		if len(toBeDeleted) == 0 && len(toBeCreated) == 0 {
 			r.log.Info("no routes updated", "table", *table.RouteTableId)
 		}
+
+		result.countActualRoutes(&tableResult)
+		result.Tables = append(result.Tables, tableResult)
+	}
+
+	for destination := range desired {
+		if !failed[destination] {
+			result.SuccessfulRoutes[destination] = true
+		}
+	}
+
+	r.backoff.record(attempts, now)
+	for _, destination := range sortedDestinations(attempts) {
+		if routeErr := attempts[destination]; routeErr != nil {
+			result.Failures[destination] = &RouteFailure{Reason: routeErr.Reason(), Message: routeErr.Message()}
+			updateErrors = multierr.Append(updateErrors, routeErr)
+		}
+	}
+	result.NextRetry = r.backoff.nextRetry()
+	result.Quarantined = r.backoff.quarantined()
+
+	updateErrors = multierr.Append(updateErrors, r.updateTransitGatewayRoutes(ctx, result, tick))
+	pending, err := r.updatePrefixList(ctx, allRoutes, tick)
+	updateErrors = multierr.Append(updateErrors, err)
+	if requeue := now.Add(prefixListRequeueDelay); pending && (result.NextRetry.IsZero() || requeue.Before(result.NextRetry)) {
+		result.NextRetry = requeue
+	}
+	updateErrors = multierr.Append(updateErrors, r.updateSecurityGroupRules(ctx, tick))
+
+	if r.statusReporter != nil {
+		if err := r.statusReporter.ReportStatus(ctx, result); err != nil {
+			r.log.Error(err, "reporting route table status failed")
+		}
 	}
 
 	return result, updateErrors
 }
 
+// createNodeRoute creates the route of a node and records the outcome in the result
+func (r *CustomRoutes) createNodeRoute(ctx context.Context, table ec2types.RouteTable, create internalNodeRoute, result *RouteUpdateResult, tick func()) *RouteError {
+	networkInterfaceIds, err := r.getNetworkInterfaces(ctx, create.instanceId)
+	if err != nil {
+		result.addOutcome(RouteOperationCreate, table, create, err)
+		return newRouteError(RouteOperationCreate, *table.RouteTableId, create, "", fmt.Errorf("getting network interfaces failed: %w", err))
+	}
+
+	// Multi-NIC instances: AWS rejects InstanceId in CreateRoute, must use NetworkInterfaceId.
+	// Try each ENI (sorted by device index), first success wins.
+	if len(networkInterfaceIds) > 1 {
+		routeCreated := false
+		var eniId string
+		for _, eniId = range networkInterfaceIds {
+			req := &ec2.CreateRouteInput{
+				DestinationCidrBlock: aws.String(create.destinationCidrBlock),
+				NetworkInterfaceId:   aws.String(eniId),
+				RouteTableId:         table.RouteTableId,
+			}
+			tick()
+			_, err = r.ec2.CreateRoute(ctx, req)
+			if err == nil {
+				r.log.Info("route created", "table", *table.RouteTableId, "destination", create.destinationCidrBlock, "instanceId", create.instanceId, "networkInterfaceId", eniId)
+				routeCreated = true
+				break
+			}
+		}
+		result.addOutcome(RouteOperationCreate, table, create, err)
+		if !routeCreated {
+			return newRouteError(RouteOperationCreate, *table.RouteTableId, create, eniId, fmt.Errorf("failed on all ENIs: %w", err))
+		}
+		return nil
+	}
+
+	// Single NIC: use InstanceId as before
+	req := &ec2.CreateRouteInput{
+		DestinationCidrBlock: aws.String(create.destinationCidrBlock),
+		InstanceId:           aws.String(create.instanceId),
+		RouteTableId:         table.RouteTableId,
+	}
+	tick()
+	_, err = r.ec2.CreateRoute(ctx, req)
+	result.addOutcome(RouteOperationCreate, table, create, err)
+	if err != nil {
+		return newRouteError(RouteOperationCreate, *table.RouteTableId, create, "", err)
+	}
+	r.log.Info("route created", "table", *table.RouteTableId, "destination", create.destinationCidrBlock, "instanceId", create.instanceId)
+	return nil
+}
+
+// createPeeringRoute creates the route for the pod CIDR of a node in a peered VPC to the VPC peering connection
+// and records the outcome in the result
+func (r *CustomRoutes) createPeeringRoute(ctx context.Context, table ec2types.RouteTable, create internalNodeRoute, result *RouteUpdateResult, tick func()) *RouteError {
+	req := &ec2.CreateRouteInput{
+		DestinationCidrBlock:   aws.String(create.destinationCidrBlock),
+		VpcPeeringConnectionId: aws.String(create.vpcPeeringConnectionId),
+		RouteTableId:           table.RouteTableId,
+	}
+	tick()
+	_, err := r.ec2.CreateRoute(ctx, req)
+	result.addOutcome(RouteOperationCreate, table, create, err)
+	if err != nil {
+		return newRouteError(RouteOperationCreate, *table.RouteTableId, create, "", err)
+	}
+	r.log.Info("route created", "table", *table.RouteTableId, "destination", create.destinationCidrBlock, "vpcPeeringConnectionId", create.vpcPeeringConnectionId)
+	return nil
+}
+
+// deleteRoutes deletes the given routes from the route table and records the outcomes in the result
+func (r *CustomRoutes) deleteRoutes(ctx context.Context, table ec2types.RouteTable, toBeDeleted []internalNodeRoute, result *RouteUpdateResult, tick func()) error {
+	var deleteErrors error
+	for _, del := range toBeDeleted {
+		req := &ec2.DeleteRouteInput{
+			RouteTableId:         table.RouteTableId,
+			DestinationCidrBlock: aws.String(del.destinationCidrBlock),
+		}
+		tick()
+		_, err := r.ec2.DeleteRoute(ctx, req)
+		result.addOutcome(RouteOperationDelete, table, del, err)
+		if err != nil {
+			deleteErrors = multierr.Append(deleteErrors, newRouteError(RouteOperationDelete, *table.RouteTableId, del, "", err))
+			continue
+		}
+		r.log.Info("route deleted", "table", *table.RouteTableId, "destination", del.destinationCidrBlock, "instanceId", del.instanceId)
+	}
+	return deleteErrors
+}
+
+// countActualRoutes updates the actual routes of the table result with the successful outcomes
+func (r *RouteUpdateResult) countActualRoutes(tableResult *RouteTableResult) {
+	for _, outcome := range r.Outcomes {
+		if outcome.TableID != tableResult.TableID || outcome.Err != nil {
+			continue
+		}
+		switch outcome.Operation {
+		case RouteOperationCreate:
+			tableResult.ActualRoutes++
+			if tableResult.RouteLimit > 0 {
+				tableResult.TotalRoutes++
+			}
+		case RouteOperationReplace:
+			tableResult.ActualRoutes++
+		case RouteOperationDelete:
+			tableResult.ActualRoutes--
+			if tableResult.RouteLimit > 0 {
+				tableResult.TotalRoutes--
+			}
+		}
+	}
+}
+
+// newRouteTableResult creates the result for a route table with the state before the update
+func (r *CustomRoutes) newRouteTableResult(table ec2types.RouteTable, plan tablePlan) RouteTableResult {
+	result := RouteTableResult{
+		TableID: aws.ToString(table.RouteTableId),
+		VPCID:   aws.ToString(table.VpcId),
+	}
+	if !r.isMainTable(table) {
+		result.DesiredRoutes = len(plan.nodeRoutes) + len(plan.peeringRoutes)
+	}
+	for _, route := range table.Routes {
+		if !r.isPodNetworkRoute(route) {
+			continue
+		}
+		if route.Origin != ec2types.RouteOriginCreateRoute || r.findProtectedNetwork(*route.DestinationCidrBlock) != nil {
+			result.UnmanagedRoutes = append(result.UnmanagedRoutes, UnmanagedRoute{
+				Destination: *route.DestinationCidrBlock,
+				Target:      routeTarget(route),
+				Origin:      string(route.Origin),
+			})
+			continue
+		}
+		result.ActualRoutes++
+	}
+	return result
+}
+
+// routeTarget returns the ID of the target of a route
+func routeTarget(route ec2types.Route) string {
+	for _, target := range []*string{
+		route.InstanceId,
+		route.NetworkInterfaceId,
+		route.GatewayId,
+		route.NatGatewayId,
+		route.TransitGatewayId,
+		route.VpcPeeringConnectionId,
+		route.LocalGatewayId,
+		route.CarrierGatewayId,
+		route.EgressOnlyInternetGatewayId,
+		route.CoreNetworkArn,
+	} {
+		if target != nil {
+			return *target
+		}
+	}
+	return ""
+}
+
+// filterProtectedRoutes removes all node routes overlapping a protected CIDR and records them as failed
+func (r *CustomRoutes) filterProtectedRoutes(routes []NodeRoute, result *RouteUpdateResult) []NodeRoute {
+	if len(r.protectedNetworks) == 0 {
+		return routes
+	}
+	var filtered []NodeRoute
+	for _, route := range routes {
+		if protected := r.findProtectedNetwork(route.PodCIDR); protected != nil {
+			r.log.Info("skipping route creation for protected CIDR", "destination", route.PodCIDR, "instanceId", route.InstanceID, "protectedCIDR", protected.String())
+			result.Failures[route.PodCIDR] = &RouteFailure{
+				Reason:  ReasonPodCIDRProtected,
+				Message: fmt.Sprintf("pod CIDR %s overlaps protected CIDR %s", route.PodCIDR, protected.String()),
+			}
+			continue
+		}
+		filtered = append(filtered, route)
+	}
+	return filtered
+}
+
+// findProtectedNetwork returns the first protected network overlapping the given CIDR or nil
+func (r *CustomRoutes) findProtectedNetwork(cidr string) *net.IPNet {
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil
+	}
+	for i := range r.protectedNetworks {
+		if util.NetworksOverlap(&r.protectedNetworks[i], ipnet) {
+			return &r.protectedNetworks[i]
+		}
+	}
+	return nil
+}
+
+func parseNetworks(cidrs []string) ([]net.IPNet, error) {
+	var networks []net.IPNet
+	for _, cidr := range cidrs {
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, *ipnet)
+	}
+	return networks, nil
+}
+
+func (r *RouteUpdateResult) addOutcome(op RouteOperation, table ec2types.RouteTable, route internalNodeRoute, err error) {
+	r.Outcomes = append(r.Outcomes, RouteOutcome{
+		Operation:              op,
+		TableID:                aws.ToString(table.RouteTableId),
+		Destination:            route.destinationCidrBlock,
+		InstanceID:             route.instanceId,
+		VPCPeeringConnectionID: route.vpcPeeringConnectionId,
+		Err:                    err,
+	})
+}
+
 func (r *CustomRoutes) isMainTable(table ec2types.RouteTable) bool {
 	return getNameTagValue(table.Tags) == r.clusterName
 }
 
-func (r *CustomRoutes) calcRouteChanges(table ec2types.RouteTable, nodeRoutes []NodeRoute) (toBeCreated, toBeDeleted []internalNodeRoute) {
+// inPodNetworks checks if the given IP is contained in any of the managed pod networks
+func (r *CustomRoutes) inPodNetworks(ip net.IP) bool {
+	for _, podNetwork := range r.podNetworks {
+		if podNetwork.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPodNetworkRoute checks if the route has an IPv4 destination in one of the pod networks
+func (r *CustomRoutes) isPodNetworkRoute(route ec2types.Route) bool {
+	if route.DestinationCidrBlock == nil {
+		return false
+	}
+	_, ipnet, err := net.ParseCIDR(*route.DestinationCidrBlock)
+	return err == nil && r.inPodNetworks(ipnet.IP)
+}
+
+func (r *CustomRoutes) calcRouteChanges(table ec2types.RouteTable, plan tablePlan) (toBeCreated, toBeDeleted []internalNodeRoute) {
 	if r.isMainTable(table) {
-		nodeRoutes = nil
+		plan = tablePlan{}
 	}
+	nodeRoutes := plan.nodeRoutes
+	peeringRoutes := plan.peeringRoutes
 	found := make([]bool, len(nodeRoutes))
+	foundPeering := make([]bool, len(peeringRoutes))
 outer:
 	for _, route := range table.Routes {
-		if route.Origin != ec2types.RouteOriginCreateRoute {
-			continue
-		}
-		if route.DestinationCidrBlock == nil {
-			continue
-		}
-		if _, ipnet, err := net.ParseCIDR(*route.DestinationCidrBlock); err != nil || !r.podNetwork.Contains(ipnet.IP) {
+		if route.Origin != ec2types.RouteOriginCreateRoute || !r.isPodNetworkRoute(route) {
 			continue
 		}
 		for i, nr := range nodeRoutes {
@@ -247,8 +662,20 @@ outer:
 				continue outer
 			}
 		}
+		for i, pr := range peeringRoutes {
+			if pr.destinationCidrBlock == *route.DestinationCidrBlock && aws.ToString(route.VpcPeeringConnectionId) == pr.vpcPeeringConnectionId {
+				foundPeering[i] = true
+				continue outer
+			}
+		}
+		if protected := r.findProtectedNetwork(*route.DestinationCidrBlock); protected != nil {
+			r.log.Info("skipping route deletion for protected CIDR", "table", aws.ToString(table.RouteTableId), "destination", *route.DestinationCidrBlock, "protectedCIDR", protected.String())
+			continue
+		}
 		toBeDeleted = append(toBeDeleted, internalNodeRoute{
-			destinationCidrBlock: *route.DestinationCidrBlock,
+			destinationCidrBlock:   *route.DestinationCidrBlock,
+			instanceId:             aws.ToString(route.InstanceId),
+			vpcPeeringConnectionId: aws.ToString(route.VpcPeeringConnectionId),
 		})
 	}
 
@@ -262,6 +689,11 @@ outer:
 		}
 		toBeCreated = append(toBeCreated, route)
 	}
+	for i, pr := range peeringRoutes {
+		if !foundPeering[i] {
+			toBeCreated = append(toBeCreated, pr)
+		}
+	}
 
 	return
 }
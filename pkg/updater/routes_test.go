@@ -6,13 +6,17 @@ package updater_test
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/wait"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
@@ -55,6 +59,16 @@ var _ = Describe("CustomRoutes", func() {
 			InstanceId:           aws.String("i-node3"),
 			Origin:               ec2types.RouteOriginCreateRoute,
 		}
+		routeNode4 = ec2types.Route{
+			DestinationCidrBlock: aws.String("10.250.4.0/24"),
+			InstanceId:           aws.String("i-node4"),
+			Origin:               ec2types.RouteOriginCreateRoute,
+		}
+		routeOther = ec2types.Route{
+			DestinationCidrBlock: aws.String("10.251.4.0/24"),
+			InstanceId:           aws.String("i-other"),
+			Origin:               ec2types.RouteOriginCreateRoute,
+		}
 		rt1    = aws.String("rt1")
 		rt2    = aws.String("rt2")
 		rt3    = aws.String("rt3")
@@ -96,6 +110,19 @@ var _ = Describe("CustomRoutes", func() {
 				},
 			},
 		}
+		tables3 = []ec2types.RouteTable{
+			{
+				RouteTableId: rt1,
+				Tags:         []ec2types.Tag{clusterTag},
+				Routes: []ec2types.Route{
+					route1,
+					routeNode1,
+					routeNode3,
+					routeNode4,
+					routeOther,
+				},
+			},
+		}
 		nodeRoutes = []updater.NodeRoute{
 			{
 				InstanceID: *routeNode1.InstanceId,
@@ -115,7 +142,7 @@ var _ = Describe("CustomRoutes", func() {
 		ec2RoutesMock = updater.NewMockEC2Routes(ctrl)
 
 		var err error
-		customRoutes, err = updater.NewCustomRoutes(logf.Log.WithName("test"), ec2RoutesMock, clusterName, "10.243.0.0/19")
+		customRoutes, err = updater.NewCustomRoutes(logf.Log.WithName("test"), ec2RoutesMock, clusterName, []string{"10.243.0.0/19", "10.250.0.0/19"})
 		Expect(err).To(BeNil())
 	})
 
@@ -206,6 +233,16 @@ var _ = Describe("CustomRoutes", func() {
 		Expect(result).NotTo(BeNil())
 		Expect(result.SuccessfulRoutes[nodeRoutes[0].PodCIDR]).To(BeTrue())
 		Expect(result.SuccessfulRoutes[nodeRoutes[1].PodCIDR]).To(BeTrue())
+		Expect(result.Outcomes).To(ConsistOf(
+			updater.RouteOutcome{Operation: updater.RouteOperationDelete, TableID: *rt1, Destination: *routeNode2.DestinationCidrBlock, InstanceID: *routeNode2.InstanceId},
+			updater.RouteOutcome{Operation: updater.RouteOperationCreate, TableID: *rt1, Destination: nodeRoutes[1].PodCIDR, InstanceID: nodeRoutes[1].InstanceID},
+			updater.RouteOutcome{Operation: updater.RouteOperationCreate, TableID: *rt2, Destination: nodeRoutes[0].PodCIDR, InstanceID: nodeRoutes[0].InstanceID},
+			updater.RouteOutcome{Operation: updater.RouteOperationCreate, TableID: *rt2, Destination: nodeRoutes[1].PodCIDR, InstanceID: nodeRoutes[1].InstanceID},
+		))
+		Expect(result.Tables).To(Equal([]updater.RouteTableResult{
+			{TableID: *rt1, DesiredRoutes: 2, ActualRoutes: 2},
+			{TableID: *rt2, DesiredRoutes: 2, ActualRoutes: 2},
+		}))
 	})
 
 	It("should update nothing if unchanged", func() {
@@ -217,4 +254,712 @@ var _ = Describe("CustomRoutes", func() {
 		Expect(result.SuccessfulRoutes[nodeRoutes[1].PodCIDR]).To(BeTrue())
 	})
 
+	It("should manage routes of all pod networks", func() {
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables3}, nil)
+		ec2RoutesMock.EXPECT().DeleteRoute(ctx, &ec2.DeleteRouteInput{
+			DestinationCidrBlock: routeNode4.DestinationCidrBlock,
+			RouteTableId:         rt1,
+		})
+		result, err := customRoutes.Update(ctx, nodeRoutes, func() {})
+		Expect(err).To(BeNil())
+		Expect(result.SuccessfulRoutes[nodeRoutes[0].PodCIDR]).To(BeTrue())
+		Expect(result.SuccessfulRoutes[nodeRoutes[1].PodCIDR]).To(BeTrue())
+	})
+
+	It("should require a pod network", func() {
+		_, err := updater.NewCustomRoutes(logf.Log.WithName("test"), ec2RoutesMock, clusterName, nil)
+		Expect(err).NotTo(BeNil())
+	})
+	It("should neither create nor delete routes in protected CIDRs", func() {
+		Expect(customRoutes.SetProtectedCIDRs([]string{"10.243.8.0/22", "10.243.12.0/22"})).To(Succeed())
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables[:1]}, nil)
+		result, err := customRoutes.Update(ctx, nodeRoutes, func() {})
+		Expect(err).To(BeNil())
+		Expect(result.SuccessfulRoutes[nodeRoutes[0].PodCIDR]).To(BeTrue())
+		Expect(result.SuccessfulRoutes[nodeRoutes[1].PodCIDR]).To(BeFalse())
+		Expect(result.Failures[nodeRoutes[1].PodCIDR]).NotTo(BeNil())
+		Expect(result.Failures[nodeRoutes[1].PodCIDR].Reason).To(Equal(updater.ReasonPodCIDRProtected))
+		Expect(result.Tables).To(HaveLen(1))
+		Expect(result.Tables[0].UnmanagedRoutes).To(Equal([]updater.UnmanagedRoute{
+			{Destination: *routeNode2.DestinationCidrBlock, Target: *routeNode2.InstanceId, Origin: string(ec2types.RouteOriginCreateRoute)},
+		}))
+	})
+	It("should calculate the diff without changing routes", func() {
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables}, nil)
+		diffs, err := customRoutes.Diff(ctx, nodeRoutes)
+		Expect(err).To(BeNil())
+		Expect(diffs).To(Equal([]updater.RouteTableDiff{
+			{
+				TableID:     *rt1,
+				ToBeCreated: []updater.NodeRoute{nodeRoutes[1]},
+				ToBeDeleted: []updater.NodeRoute{{PodCIDR: *routeNode2.DestinationCidrBlock, InstanceID: *routeNode2.InstanceId}},
+				Unchanged:   []updater.NodeRoute{nodeRoutes[0]},
+			},
+			{
+				TableID:     *rt2,
+				ToBeCreated: nodeRoutes,
+			},
+		}))
+	})
+	It("should delete all managed routes on cleanup", func() {
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables}, nil)
+		ec2RoutesMock.EXPECT().DeleteRoute(ctx, &ec2.DeleteRouteInput{
+			DestinationCidrBlock: routeNode1.DestinationCidrBlock,
+			RouteTableId:         rt1,
+		})
+		ec2RoutesMock.EXPECT().DeleteRoute(ctx, &ec2.DeleteRouteInput{
+			DestinationCidrBlock: routeNode2.DestinationCidrBlock,
+			RouteTableId:         rt1,
+		})
+		result, err := customRoutes.Cleanup(ctx, wait.Backoff{Steps: 1})
+		Expect(err).To(BeNil())
+		Expect(result.Outcomes).To(HaveLen(2))
+		Expect(result.Tables).To(Equal([]updater.RouteTableResult{
+			{TableID: *rt1},
+			{TableID: *rt2},
+		}))
+	})
+	It("should retry cleanup on errors without AWS error code and report the last attempt", func() {
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(nil, errors.New("unable to find route table"))
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables}, nil)
+		ec2RoutesMock.EXPECT().DeleteRoute(ctx, &ec2.DeleteRouteInput{
+			DestinationCidrBlock: routeNode1.DestinationCidrBlock,
+			RouteTableId:         rt1,
+		})
+		ec2RoutesMock.EXPECT().DeleteRoute(ctx, &ec2.DeleteRouteInput{
+			DestinationCidrBlock: routeNode2.DestinationCidrBlock,
+			RouteTableId:         rt1,
+		}).Return(nil, errors.New("connection reset"))
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: []ec2types.RouteTable{
+			{RouteTableId: rt1, Tags: []ec2types.Tag{clusterTag}, Routes: []ec2types.Route{route1, routeNode2}},
+		}}, nil)
+		ec2RoutesMock.EXPECT().DeleteRoute(ctx, &ec2.DeleteRouteInput{
+			DestinationCidrBlock: routeNode2.DestinationCidrBlock,
+			RouteTableId:         rt1,
+		})
+		result, err := customRoutes.Cleanup(ctx, wait.Backoff{Steps: 3, Duration: time.Millisecond})
+		Expect(err).To(BeNil())
+		Expect(result.Outcomes).To(HaveLen(1))
+		Expect(result.Outcomes[0].Err).To(BeNil())
+		Expect(result.Tables).To(Equal([]updater.RouteTableResult{{TableID: *rt1}}))
+	})
+	It("should abort cleanup on permanent AWS errors", func() {
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(nil, &smithy.GenericAPIError{Code: "UnauthorizedOperation"})
+		_, err := customRoutes.Cleanup(ctx, wait.Backoff{Steps: 3, Duration: time.Millisecond})
+		Expect(updater.IsPermanent(err)).To(BeTrue())
+	})
+	It("should calculate the diff from a route table snapshot", func() {
+		snapshot := []byte(`{"RouteTables": [{"RouteTableId": "rt1", "VpcId": "vpc1",
+			"Tags": [{"Key": "kubernetes.io/cluster/shoot--foo--bar", "Value": "1"}],
+			"Routes": [
+				{"DestinationCidrBlock": "10.243.3.0/24", "InstanceId": "i-node1", "Origin": "CreateRoute"},
+				{"DestinationCidrBlock": "10.243.9.0/24", "InstanceId": "i-node2", "Origin": "CreateRoute"}
+			]}]}`)
+		snapshotRoutes, err := updater.NewSnapshotEC2Routes(snapshot)
+		Expect(err).To(BeNil())
+		offlineRoutes, err := updater.NewCustomRoutes(logf.Log.WithName("test"), snapshotRoutes, clusterName, []string{"10.243.0.0/19"})
+		Expect(err).To(BeNil())
+
+		diffs, err := offlineRoutes.Diff(ctx, nodeRoutes)
+		Expect(err).To(BeNil())
+		Expect(diffs).To(Equal([]updater.RouteTableDiff{
+			{
+				TableID:     "rt1",
+				VPCID:       "vpc1",
+				ToBeCreated: []updater.NodeRoute{nodeRoutes[1]},
+				ToBeDeleted: []updater.NodeRoute{{PodCIDR: "10.243.9.0/24", InstanceID: "i-node2"}},
+				Unchanged:   []updater.NodeRoute{nodeRoutes[0]},
+			},
+		}))
+		_, err = offlineRoutes.Update(ctx, nodeRoutes, func() {})
+		Expect(err).NotTo(BeNil())
+	})
+	It("should keep the pod network routes in transit gateway route tables", func() {
+		customRoutes.SetTransitGateway([]string{"tgw-rtb-1", "tgw-rtb-2"}, "tgw-attach-1")
+		ownRoute := func(cidr string) ec2types.TransitGatewayRoute {
+			return ec2types.TransitGatewayRoute{
+				DestinationCidrBlock:      aws.String(cidr),
+				State:                     ec2types.TransitGatewayRouteStateActive,
+				TransitGatewayAttachments: []ec2types.TransitGatewayRouteAttachment{{TransitGatewayAttachmentId: aws.String("tgw-attach-1")}},
+			}
+		}
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables2}, nil)
+		ec2RoutesMock.EXPECT().SearchTransitGatewayRoutes(ctx, &ec2.SearchTransitGatewayRoutesInput{
+			TransitGatewayRouteTableId: aws.String("tgw-rtb-1"),
+			Filters: []ec2types.Filter{
+				{Name: aws.String("type"), Values: []string{"static"}},
+				{Name: aws.String("route-search.exact-match"), Values: []string{"10.243.0.0/19", "10.250.0.0/19"}},
+			},
+			MaxResults: aws.Int32(1000),
+		}).Return(&ec2.SearchTransitGatewayRoutesOutput{Routes: []ec2types.TransitGatewayRoute{
+			{DestinationCidrBlock: aws.String("10.250.0.0/19"), State: ec2types.TransitGatewayRouteStateBlackhole},
+		}}, nil)
+		ec2RoutesMock.EXPECT().CreateTransitGatewayRoute(ctx, &ec2.CreateTransitGatewayRouteInput{
+			DestinationCidrBlock:       aws.String("10.243.0.0/19"),
+			TransitGatewayRouteTableId: aws.String("tgw-rtb-1"),
+			TransitGatewayAttachmentId: aws.String("tgw-attach-1"),
+		})
+		ec2RoutesMock.EXPECT().ReplaceTransitGatewayRoute(ctx, &ec2.ReplaceTransitGatewayRouteInput{
+			DestinationCidrBlock:       aws.String("10.250.0.0/19"),
+			TransitGatewayRouteTableId: aws.String("tgw-rtb-1"),
+			TransitGatewayAttachmentId: aws.String("tgw-attach-1"),
+		})
+		ec2RoutesMock.EXPECT().SearchTransitGatewayRoutes(ctx, gomock.Any()).Return(&ec2.SearchTransitGatewayRoutesOutput{Routes: []ec2types.TransitGatewayRoute{
+			ownRoute("10.243.0.0/19"), ownRoute("10.250.0.0/19"),
+		}}, nil).Times(3)
+
+		result, err := customRoutes.Update(ctx, nodeRoutes, func() {})
+		Expect(err).To(BeNil())
+		Expect(result.Outcomes).To(ConsistOf(
+			updater.RouteOutcome{Operation: updater.RouteOperationCreate, TableID: "tgw-rtb-1", Destination: "10.243.0.0/19", TransitGatewayAttachmentID: "tgw-attach-1"},
+			updater.RouteOutcome{Operation: updater.RouteOperationReplace, TableID: "tgw-rtb-1", Destination: "10.250.0.0/19", TransitGatewayAttachmentID: "tgw-attach-1"},
+		))
+		Expect(result.Tables).To(Equal([]updater.RouteTableResult{
+			{TableID: *rt1, DesiredRoutes: 2, ActualRoutes: 2},
+		}))
+		Expect(result.TransitGatewayTables).To(Equal([]updater.RouteTableResult{
+			{TableID: "tgw-rtb-1", DesiredRoutes: 2, ActualRoutes: 2},
+			{TableID: "tgw-rtb-2", DesiredRoutes: 2, ActualRoutes: 2},
+		}))
+	})
+	It("should only delete stale transit gateway routes inside the pod networks", func() {
+		customRoutes.SetTransitGateway([]string{"tgw-rtb-1"}, "tgw-attach-1")
+		Expect(customRoutes.SetProtectedCIDRs([]string{"10.243.8.0/22"})).To(Succeed())
+		ownRoute := func(cidr string) ec2types.TransitGatewayRoute {
+			return ec2types.TransitGatewayRoute{
+				DestinationCidrBlock:      aws.String(cidr),
+				State:                     ec2types.TransitGatewayRouteStateActive,
+				TransitGatewayAttachments: []ec2types.TransitGatewayRouteAttachment{{TransitGatewayAttachmentId: aws.String("tgw-attach-1")}},
+			}
+		}
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables2}, nil)
+		ec2RoutesMock.EXPECT().SearchTransitGatewayRoutes(ctx, gomock.Any()).Return(&ec2.SearchTransitGatewayRoutesOutput{Routes: []ec2types.TransitGatewayRoute{
+			ownRoute("10.243.0.0/19"), ownRoute("10.250.0.0/19"),
+		}}, nil)
+		ec2RoutesMock.EXPECT().SearchTransitGatewayRoutes(ctx, &ec2.SearchTransitGatewayRoutesInput{
+			TransitGatewayRouteTableId: aws.String("tgw-rtb-1"),
+			Filters: []ec2types.Filter{
+				{Name: aws.String("type"), Values: []string{"static"}},
+				{Name: aws.String("attachment.transit-gateway-attachment-id"), Values: []string{"tgw-attach-1"}},
+			},
+			MaxResults: aws.Int32(1000),
+		}).Return(&ec2.SearchTransitGatewayRoutesOutput{Routes: []ec2types.TransitGatewayRoute{
+			ownRoute("10.243.0.0/19"), ownRoute("10.250.0.0/19"), ownRoute("10.243.4.0/24"), ownRoute("10.243.8.0/24"), ownRoute("10.200.0.0/19"),
+		}}, nil)
+		ec2RoutesMock.EXPECT().DeleteTransitGatewayRoute(ctx, &ec2.DeleteTransitGatewayRouteInput{
+			DestinationCidrBlock:       aws.String("10.243.4.0/24"),
+			TransitGatewayRouteTableId: aws.String("tgw-rtb-1"),
+		})
+
+		result, err := customRoutes.Update(ctx, nodeRoutes, func() {})
+		Expect(err).To(BeNil())
+		Expect(result.Outcomes).To(ConsistOf(
+			updater.RouteOutcome{Operation: updater.RouteOperationDelete, TableID: "tgw-rtb-1", Destination: "10.243.4.0/24", TransitGatewayAttachmentID: "tgw-attach-1"},
+		))
+		Expect(result.TransitGatewayTables).To(Equal([]updater.RouteTableResult{
+			{TableID: "tgw-rtb-1", DesiredRoutes: 2, ActualRoutes: 2},
+		}))
+	})
+	It("should only delete the managed transit gateway routes on cleanup", func() {
+		customRoutes.SetTransitGateway([]string{"tgw-rtb-1"}, "tgw-attach-1")
+		ownRoute := func(cidr string) ec2types.TransitGatewayRoute {
+			return ec2types.TransitGatewayRoute{
+				DestinationCidrBlock:      aws.String(cidr),
+				State:                     ec2types.TransitGatewayRouteStateActive,
+				TransitGatewayAttachments: []ec2types.TransitGatewayRouteAttachment{{TransitGatewayAttachmentId: aws.String("tgw-attach-1")}},
+			}
+		}
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables2[:1]}, nil)
+		ec2RoutesMock.EXPECT().DeleteRoute(ctx, gomock.Any()).Times(2)
+		ec2RoutesMock.EXPECT().SearchTransitGatewayRoutes(ctx, gomock.Any()).Return(&ec2.SearchTransitGatewayRoutesOutput{Routes: []ec2types.TransitGatewayRoute{
+			ownRoute("10.243.0.0/19"), ownRoute("10.200.0.0/19"),
+		}}, nil)
+		ec2RoutesMock.EXPECT().DeleteTransitGatewayRoute(ctx, &ec2.DeleteTransitGatewayRouteInput{
+			DestinationCidrBlock:       aws.String("10.243.0.0/19"),
+			TransitGatewayRouteTableId: aws.String("tgw-rtb-1"),
+		})
+
+		result, err := customRoutes.Cleanup(ctx, wait.Backoff{Steps: 1})
+		Expect(err).To(BeNil())
+		Expect(result.TransitGatewayTables).To(Equal([]updater.RouteTableResult{{TableID: "tgw-rtb-1"}}))
+	})
+	It("should fail on truncated transit gateway route searches", func() {
+		customRoutes.SetTransitGateway([]string{"tgw-rtb-1"}, "tgw-attach-1")
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables2}, nil)
+		ec2RoutesMock.EXPECT().SearchTransitGatewayRoutes(ctx, gomock.Any()).Return(&ec2.SearchTransitGatewayRoutesOutput{AdditionalRoutesAvailable: aws.Bool(true)}, nil)
+
+		_, err := customRoutes.Update(ctx, nodeRoutes, func() {})
+		Expect(err).To(MatchError(ContainSubstring("returned more than 1000 routes")))
+	})
+	It("should create transit gateway routes for pod networks containing protected CIDRs", func() {
+		customRoutes.SetTransitGateway([]string{"tgw-rtb-1"}, "tgw-attach-1")
+		Expect(customRoutes.SetProtectedCIDRs([]string{"10.243.8.0/22"})).To(Succeed())
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables2}, nil)
+		ec2RoutesMock.EXPECT().SearchTransitGatewayRoutes(ctx, gomock.Any()).Return(&ec2.SearchTransitGatewayRoutesOutput{}, nil).Times(2)
+		ec2RoutesMock.EXPECT().CreateTransitGatewayRoute(ctx, &ec2.CreateTransitGatewayRouteInput{
+			DestinationCidrBlock:       aws.String("10.243.0.0/19"),
+			TransitGatewayRouteTableId: aws.String("tgw-rtb-1"),
+			TransitGatewayAttachmentId: aws.String("tgw-attach-1"),
+		})
+		ec2RoutesMock.EXPECT().CreateTransitGatewayRoute(ctx, &ec2.CreateTransitGatewayRouteInput{
+			DestinationCidrBlock:       aws.String("10.250.0.0/19"),
+			TransitGatewayRouteTableId: aws.String("tgw-rtb-1"),
+			TransitGatewayAttachmentId: aws.String("tgw-attach-1"),
+		})
+
+		_, err := customRoutes.Update(ctx, nodeRoutes, func() {})
+		Expect(err).To(BeNil())
+	})
+	It("should not take over transit gateway routes of other attachments", func() {
+		customRoutes.SetTransitGateway([]string{"tgw-rtb-1"}, "tgw-attach-1")
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables2}, nil)
+		ec2RoutesMock.EXPECT().SearchTransitGatewayRoutes(ctx, gomock.Any()).Return(&ec2.SearchTransitGatewayRoutesOutput{Routes: []ec2types.TransitGatewayRoute{
+			{
+				DestinationCidrBlock:      aws.String("10.243.0.0/19"),
+				State:                     ec2types.TransitGatewayRouteStateActive,
+				TransitGatewayAttachments: []ec2types.TransitGatewayRouteAttachment{{TransitGatewayAttachmentId: aws.String("tgw-attach-other")}},
+			},
+		}}, nil)
+		ec2RoutesMock.EXPECT().SearchTransitGatewayRoutes(ctx, gomock.Any()).Return(&ec2.SearchTransitGatewayRoutesOutput{}, nil)
+		ec2RoutesMock.EXPECT().CreateTransitGatewayRoute(ctx, &ec2.CreateTransitGatewayRouteInput{
+			DestinationCidrBlock:       aws.String("10.250.0.0/19"),
+			TransitGatewayRouteTableId: aws.String("tgw-rtb-1"),
+			TransitGatewayAttachmentId: aws.String("tgw-attach-1"),
+		})
+
+		_, err := customRoutes.Update(ctx, nodeRoutes, func() {})
+		Expect(err).To(MatchError(ContainSubstring("points at another attachment")))
+	})
+	It("should keep the managed prefix list in sync with the node pod CIDRs", func() {
+		customRoutes.SetPrefixList("pl-1", updater.PrefixListContentNodePodCIDRs)
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables2}, nil)
+		ec2RoutesMock.EXPECT().DescribeManagedPrefixLists(ctx, &ec2.DescribeManagedPrefixListsInput{PrefixListIds: []string{"pl-1"}}).Return(&ec2.DescribeManagedPrefixListsOutput{
+			PrefixLists: []ec2types.ManagedPrefixList{{PrefixListId: aws.String("pl-1"), Version: aws.Int64(5), MaxEntries: aws.Int32(10), State: ec2types.PrefixListStateModifyComplete}},
+		}, nil)
+		ec2RoutesMock.EXPECT().GetManagedPrefixListEntries(ctx, &ec2.GetManagedPrefixListEntriesInput{PrefixListId: aws.String("pl-1"), TargetVersion: aws.Int64(5)}, gomock.Any()).Return(&ec2.GetManagedPrefixListEntriesOutput{
+			Entries: []ec2types.PrefixListEntry{{Cidr: routeNode1.DestinationCidrBlock}, {Cidr: routeNode2.DestinationCidrBlock}},
+		}, nil)
+		ec2RoutesMock.EXPECT().ModifyManagedPrefixList(ctx, &ec2.ModifyManagedPrefixListInput{
+			PrefixListId:   aws.String("pl-1"),
+			CurrentVersion: aws.Int64(5),
+			AddEntries:     []ec2types.AddPrefixListEntry{{Cidr: routeNode3.DestinationCidrBlock, Description: routeNode3.InstanceId}},
+			RemoveEntries:  []ec2types.RemovePrefixListEntry{{Cidr: routeNode2.DestinationCidrBlock}},
+		})
+
+		_, err := customRoutes.Update(ctx, nodeRoutes, func() {})
+		Expect(err).To(BeNil())
+	})
+	It("should resize the managed prefix list with headroom before adding entries", func() {
+		customRoutes.SetPrefixList("pl-1", updater.PrefixListContentPodNetworks)
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables2}, nil)
+		ec2RoutesMock.EXPECT().DescribeManagedPrefixLists(ctx, gomock.Any()).Return(&ec2.DescribeManagedPrefixListsOutput{
+			PrefixLists: []ec2types.ManagedPrefixList{{PrefixListId: aws.String("pl-1"), Version: aws.Int64(1), MaxEntries: aws.Int32(1), State: ec2types.PrefixListStateCreateComplete}},
+		}, nil)
+		ec2RoutesMock.EXPECT().GetManagedPrefixListEntries(ctx, gomock.Any(), gomock.Any()).Return(&ec2.GetManagedPrefixListEntriesOutput{}, nil)
+		ec2RoutesMock.EXPECT().ModifyManagedPrefixList(ctx, &ec2.ModifyManagedPrefixListInput{
+			PrefixListId: aws.String("pl-1"),
+			MaxEntries:   aws.Int32(20),
+		})
+
+		result, err := customRoutes.Update(ctx, nodeRoutes, func() {})
+		Expect(err).To(BeNil())
+		Expect(result.NextRetry).NotTo(BeZero())
+
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables2}, nil)
+		ec2RoutesMock.EXPECT().DescribeManagedPrefixLists(ctx, gomock.Any()).Return(&ec2.DescribeManagedPrefixListsOutput{
+			PrefixLists: []ec2types.ManagedPrefixList{{PrefixListId: aws.String("pl-1"), Version: aws.Int64(2), MaxEntries: aws.Int32(20), State: ec2types.PrefixListStateModifyComplete}},
+		}, nil)
+		ec2RoutesMock.EXPECT().GetManagedPrefixListEntries(ctx, gomock.Any(), gomock.Any()).Return(&ec2.GetManagedPrefixListEntriesOutput{}, nil)
+		ec2RoutesMock.EXPECT().ModifyManagedPrefixList(ctx, &ec2.ModifyManagedPrefixListInput{
+			PrefixListId:   aws.String("pl-1"),
+			CurrentVersion: aws.Int64(2),
+			AddEntries: []ec2types.AddPrefixListEntry{
+				{Cidr: aws.String("10.243.0.0/19"), Description: aws.String("pod network")},
+				{Cidr: aws.String("10.250.0.0/19"), Description: aws.String("pod network")},
+			},
+		})
+
+		result, err = customRoutes.Update(ctx, nodeRoutes, func() {})
+		Expect(err).To(BeNil())
+		Expect(result.NextRetry).To(BeZero())
+	})
+	It("should fail the update if describing the managed prefix list is interrupted", func() {
+		customRoutes.SetPrefixList("pl-1", updater.PrefixListContentPodNetworks)
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables2}, nil)
+		ec2RoutesMock.EXPECT().DescribeManagedPrefixLists(ctx, gomock.Any()).Return(nil, context.DeadlineExceeded)
+
+		_, err := customRoutes.Update(ctx, nodeRoutes, func() {})
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+	It("should requeue while the managed prefix list is being modified", func() {
+		customRoutes.SetPrefixList("pl-1", updater.PrefixListContentPodNetworks)
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables2}, nil)
+		ec2RoutesMock.EXPECT().DescribeManagedPrefixLists(ctx, gomock.Any()).Return(&ec2.DescribeManagedPrefixListsOutput{
+			PrefixLists: []ec2types.ManagedPrefixList{{PrefixListId: aws.String("pl-1"), Version: aws.Int64(1), MaxEntries: aws.Int32(20), State: ec2types.PrefixListStateModifyInProgress}},
+		}, nil)
+
+		result, err := customRoutes.Update(ctx, nodeRoutes, func() {})
+		Expect(err).To(BeNil())
+		Expect(result.NextRetry).NotTo(BeZero())
+	})
+	It("should reconcile the pod network ingress rules of the tagged security groups", func() {
+		customRoutes.SetSecurityGroupRules(true)
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables2}, nil)
+		ec2RoutesMock.EXPECT().DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+			Filters: []ec2types.Filter{{Name: aws.String("tag-key"), Values: []string{updater.SecurityGroupTagKey}}},
+		}, gomock.Any()).Return(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: []ec2types.SecurityGroup{
+			{GroupId: aws.String("sg-1"), Tags: []ec2types.Tag{clusterTag}},
+			{GroupId: aws.String("sg-other-cluster")},
+		}}, nil)
+		ec2RoutesMock.EXPECT().DescribeSecurityGroupRules(ctx, &ec2.DescribeSecurityGroupRulesInput{
+			Filters: []ec2types.Filter{{Name: aws.String("group-id"), Values: []string{"sg-1"}}},
+		}, gomock.Any()).Return(&ec2.DescribeSecurityGroupRulesOutput{SecurityGroupRules: []ec2types.SecurityGroupRule{
+			{SecurityGroupRuleId: aws.String("sgr-1"), GroupId: aws.String("sg-1"), IpProtocol: aws.String("-1"), CidrIpv4: aws.String("10.243.0.0/19"), IsEgress: aws.Bool(false)},
+			{SecurityGroupRuleId: aws.String("sgr-2"), GroupId: aws.String("sg-1"), IpProtocol: aws.String("-1"), CidrIpv4: aws.String("10.250.0.0/19"), IsEgress: aws.Bool(true)},
+			{SecurityGroupRuleId: aws.String("sgr-3"), GroupId: aws.String("sg-1"), IpProtocol: aws.String("-1"), CidrIpv4: aws.String("10.99.0.0/16"), IsEgress: aws.Bool(false),
+				Description: aws.String(updater.SecurityGroupRuleDescription)},
+			{SecurityGroupRuleId: aws.String("sgr-4"), GroupId: aws.String("sg-1"), IpProtocol: aws.String("-1"), CidrIpv4: aws.String("10.98.0.0/16"), IsEgress: aws.Bool(false)},
+		}}, nil)
+		ec2RoutesMock.EXPECT().AuthorizeSecurityGroupIngress(ctx, &ec2.AuthorizeSecurityGroupIngressInput{
+			GroupId: aws.String("sg-1"),
+			IpPermissions: []ec2types.IpPermission{{
+				IpProtocol: aws.String("-1"),
+				IpRanges:   []ec2types.IpRange{{CidrIp: aws.String("10.250.0.0/19"), Description: aws.String(updater.SecurityGroupRuleDescription)}},
+			}},
+		})
+		ec2RoutesMock.EXPECT().RevokeSecurityGroupIngress(ctx, &ec2.RevokeSecurityGroupIngressInput{
+			GroupId:              aws.String("sg-1"),
+			SecurityGroupRuleIds: []string{"sgr-3"},
+		})
+
+		_, err := customRoutes.Update(ctx, nodeRoutes, func() {})
+		Expect(err).To(BeNil())
+	})
+	It("should back off and quarantine failing routes without failing the other routes", func() {
+		customRoutes.SetRouteBackoff(0, 0, 2, time.Hour)
+		goneRoute := updater.NodeRoute{InstanceID: "i-gone", PodCIDR: "10.243.20.0/24"}
+		routes := append([]updater.NodeRoute{goneRoute}, nodeRoutes...)
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables2}, nil).Times(3)
+		ec2RoutesMock.EXPECT().DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+			InstanceIds: []string{goneRoute.InstanceID},
+		}).Return(nil, &smithy.GenericAPIError{Code: "InvalidInstanceID.NotFound"}).Times(2)
+
+		for range 2 {
+			result, err := customRoutes.Update(ctx, routes, func() {})
+			Expect(updater.OnlyDestinationErrors(err)).To(BeTrue())
+			Expect(result.SuccessfulRoutes).To(Equal(map[string]bool{goneRoute.PodCIDR: false, nodeRoutes[0].PodCIDR: true, nodeRoutes[1].PodCIDR: true}))
+		}
+
+		result, err := customRoutes.Update(ctx, routes, func() {})
+		Expect(err).To(BeNil())
+		Expect(result.SuccessfulRoutes[goneRoute.PodCIDR]).To(BeFalse())
+		Expect(result.Failures[goneRoute.PodCIDR]).NotTo(BeNil())
+		Expect(result.Failures[goneRoute.PodCIDR].Reason).To(Equal(updater.ReasonRouteQuarantined))
+		Expect(result.Quarantined).To(Equal([]string{goneRoute.PodCIDR}))
+		Expect(result.NextRetry).To(BeTemporally(">", time.Now().Add(59*time.Minute)))
+	})
+	It("should report the last failure of routes in backoff even if they exist in other route tables", func() {
+		customRoutes.SetRouteBackoff(time.Hour, time.Hour, 0, 0)
+		goneRoute := updater.NodeRoute{InstanceID: "i-gone", PodCIDR: "10.243.20.0/24"}
+		backoffTables := []ec2types.RouteTable{
+			{RouteTableId: rt1, Tags: []ec2types.Tag{clusterTag}, Routes: []ec2types.Route{route1}},
+			{RouteTableId: rt2, Tags: []ec2types.Tag{clusterTag}, Routes: []ec2types.Route{route1, {
+				DestinationCidrBlock: aws.String(goneRoute.PodCIDR),
+				InstanceId:           aws.String(goneRoute.InstanceID),
+				Origin:               ec2types.RouteOriginCreateRoute,
+			}}},
+		}
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: backoffTables}, nil).Times(2)
+		ec2RoutesMock.EXPECT().DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+			InstanceIds: []string{goneRoute.InstanceID},
+		}).Return(nil, &smithy.GenericAPIError{Code: "InvalidInstanceID.NotFound"})
+
+		for range 2 {
+			result, _ := customRoutes.Update(ctx, []updater.NodeRoute{goneRoute}, func() {})
+			Expect(result.SuccessfulRoutes[goneRoute.PodCIDR]).To(BeFalse())
+			Expect(result.Failures[goneRoute.PodCIDR]).NotTo(BeNil())
+			Expect(result.Failures[goneRoute.PodCIDR].Reason).To(Equal(updater.ReasonInstanceNotFound))
+			Expect(result.Quarantined).To(BeEmpty())
+		}
+	})
+	It("should report the route limit headroom and full route tables", func() {
+		customRoutes.SetRouteQuota(updater.StaticRouteQuota(4))
+		newRoute := updater.NodeRoute{InstanceID: "i-new", PodCIDR: "10.243.20.0/24"}
+		routes := append([]updater.NodeRoute{newRoute}, nodeRoutes...)
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables2}, nil)
+		ec2RoutesMock.EXPECT().DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+			InstanceIds: []string{newRoute.InstanceID},
+		}).Return(&ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{{
+			InstanceId:        aws.String(newRoute.InstanceID),
+			NetworkInterfaces: []ec2types.InstanceNetworkInterface{{NetworkInterfaceId: aws.String("eni-new")}},
+		}}}}}, nil)
+		ec2RoutesMock.EXPECT().CreateRoute(ctx, &ec2.CreateRouteInput{
+			DestinationCidrBlock: aws.String(newRoute.PodCIDR),
+			InstanceId:           aws.String(newRoute.InstanceID),
+			RouteTableId:         rt1,
+		}).Return(nil, &smithy.GenericAPIError{Code: "RouteLimitExceeded"})
+
+		result, err := customRoutes.Update(ctx, routes, func() {})
+		Expect(updater.OnlyDestinationErrors(err)).To(BeTrue())
+		Expect(result.Tables).To(Equal([]updater.RouteTableResult{
+			// route1 is created with the route table and does not count towards the limit
+			{TableID: *rt1, DesiredRoutes: 3, ActualRoutes: 2, TotalRoutes: 3, RouteLimit: 4},
+		}))
+		headroom, ok := result.Tables[0].Headroom()
+		Expect(ok).To(BeTrue())
+		Expect(headroom).To(Equal(1))
+		Expect(result.Failures[newRoute.PodCIDR].Reason).To(Equal(updater.ReasonRouteLimitExceeded))
+	})
+	DescribeTable("should count the routes towards the route limit",
+		func(route ec2types.Route, counted bool) {
+			customRoutes.SetRouteQuota(updater.StaticRouteQuota(50))
+			natRoute := ec2types.Route{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat123"), Origin: ec2types.RouteOriginCreateRoute}
+			limitTables := []ec2types.RouteTable{{RouteTableId: rt1, Tags: []ec2types.Tag{clusterTag}, Routes: []ec2types.Route{natRoute, route}}}
+			ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: limitTables}, nil)
+
+			result, err := customRoutes.Update(ctx, nil, func() {})
+			Expect(err).To(BeNil())
+			expected := 1
+			if counted {
+				expected++
+			}
+			Expect(result.Tables[0].TotalRoutes).To(Equal(expected))
+		},
+		Entry("local route", ec2types.Route{
+			DestinationCidrBlock: aws.String("10.180.0.0/16"),
+			GatewayId:            aws.String("local"),
+			Origin:               ec2types.RouteOriginCreateRouteTable,
+		}, false),
+		Entry("local IPv6 route", ec2types.Route{
+			DestinationIpv6CidrBlock: aws.String("2600:1f18::/56"),
+			GatewayId:                aws.String("local"),
+			Origin:                   ec2types.RouteOriginCreateRouteTable,
+		}, false),
+		Entry("propagated route", ec2types.Route{
+			DestinationCidrBlock: aws.String("192.168.0.0/16"),
+			GatewayId:            aws.String("vgw-1"),
+			Origin:               ec2types.RouteOriginEnableVgwRoutePropagation,
+		}, false),
+		Entry("blackhole route", ec2types.Route{
+			DestinationCidrBlock: aws.String("172.16.0.0/12"),
+			NatGatewayId:         aws.String("nat-deleted"),
+			Origin:               ec2types.RouteOriginCreateRoute,
+			State:                ec2types.RouteStateBlackhole,
+		}, true),
+		Entry("static route", ec2types.Route{
+			DestinationCidrBlock: aws.String("172.16.0.0/12"),
+			TransitGatewayId:     aws.String("tgw-1"),
+			Origin:               ec2types.RouteOriginCreateRoute,
+		}, true),
+	)
+	It("should not quarantine routes failing with transient errors", func() {
+		customRoutes.SetRouteBackoff(0, 0, 2, time.Hour)
+		throttledRoute := updater.NodeRoute{InstanceID: "i-throttled", PodCIDR: "10.243.20.0/24"}
+		routes := append([]updater.NodeRoute{throttledRoute}, nodeRoutes...)
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: tables2}, nil).Times(3)
+		ec2RoutesMock.EXPECT().DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+			InstanceIds: []string{throttledRoute.InstanceID},
+		}).Return(nil, &smithy.GenericAPIError{Code: "RequestLimitExceeded"}).Times(3)
+
+		for range 3 {
+			result, err := customRoutes.Update(ctx, routes, func() {})
+			Expect(updater.IsTransient(err)).To(BeTrue())
+			Expect(updater.OnlyDestinationErrors(err)).To(BeFalse())
+			var routeErr *updater.RouteError
+			Expect(errors.As(err, &routeErr)).To(BeTrue())
+			Expect(routeErr.Operation).To(Equal(updater.RouteOperationCreate))
+			Expect(routeErr.Destination).To(Equal(throttledRoute.PodCIDR))
+			Expect(routeErr.InstanceID).To(Equal(throttledRoute.InstanceID))
+			Expect(routeErr.Code).To(Equal("RequestLimitExceeded"))
+			Expect(result.Failures[throttledRoute.PodCIDR].Reason).To(Equal(updater.ReasonThrottled))
+			Expect(result.Quarantined).To(BeEmpty())
+			Expect(result.NextRetry).To(BeZero())
+		}
+	})
+	It("should manage route tables across peered VPCs", func() {
+		customRoutes.SetMultiVPC(true)
+		multiVPCTables := []ec2types.RouteTable{
+			{RouteTableId: rt1, VpcId: aws.String("vpc1"), Tags: []ec2types.Tag{clusterTag}, Routes: []ec2types.Route{route1, routeNode1, routeNode3}},
+			{RouteTableId: rt2, VpcId: aws.String("vpc2"), Tags: []ec2types.Tag{clusterTag}, Routes: []ec2types.Route{route1, routeNode3}},
+			{RouteTableId: rt3, VpcId: aws.String("vpc3"), Tags: []ec2types.Tag{clusterTag}, Routes: []ec2types.Route{route1, {
+				DestinationCidrBlock:   aws.String("10.243.0.0/19"),
+				VpcPeeringConnectionId: aws.String("pcx-13"),
+				Origin:                 ec2types.RouteOriginCreateRoute,
+			}}},
+		}
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: multiVPCTables}, nil)
+		ec2RoutesMock.EXPECT().DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+			Filters: []ec2types.Filter{{Name: aws.String("instance-id"), Values: []string{nodeRoutes[0].InstanceID, nodeRoutes[1].InstanceID}}},
+		}, gomock.Any()).Return(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{
+				{InstanceId: aws.String(nodeRoutes[0].InstanceID), VpcId: aws.String("vpc1")},
+				{InstanceId: aws.String(nodeRoutes[1].InstanceID), VpcId: aws.String("vpc2")},
+			}}},
+		}, nil)
+		ec2RoutesMock.EXPECT().DescribeVpcPeeringConnections(ctx, gomock.Any(), gomock.Any()).Return(&ec2.DescribeVpcPeeringConnectionsOutput{
+			VpcPeeringConnections: []ec2types.VpcPeeringConnection{
+				{
+					VpcPeeringConnectionId: aws.String("pcx-12"),
+					RequesterVpcInfo:       &ec2types.VpcPeeringConnectionVpcInfo{VpcId: aws.String("vpc1")},
+					AccepterVpcInfo:        &ec2types.VpcPeeringConnectionVpcInfo{VpcId: aws.String("vpc2")},
+				},
+				{
+					VpcPeeringConnectionId: aws.String("pcx-13"),
+					RequesterVpcInfo:       &ec2types.VpcPeeringConnectionVpcInfo{VpcId: aws.String("vpc3")},
+					AccepterVpcInfo:        &ec2types.VpcPeeringConnectionVpcInfo{VpcId: aws.String("vpc1")},
+				},
+			},
+		}, nil)
+		ec2RoutesMock.EXPECT().DeleteRoute(ctx, &ec2.DeleteRouteInput{
+			DestinationCidrBlock: routeNode3.DestinationCidrBlock,
+			RouteTableId:         rt1,
+		})
+		ec2RoutesMock.EXPECT().CreateRoute(ctx, &ec2.CreateRouteInput{
+			DestinationCidrBlock:   routeNode3.DestinationCidrBlock,
+			VpcPeeringConnectionId: aws.String("pcx-12"),
+			RouteTableId:           rt1,
+		})
+		ec2RoutesMock.EXPECT().CreateRoute(ctx, &ec2.CreateRouteInput{
+			DestinationCidrBlock:   routeNode1.DestinationCidrBlock,
+			VpcPeeringConnectionId: aws.String("pcx-12"),
+			RouteTableId:           rt2,
+		})
+		ec2RoutesMock.EXPECT().DeleteRoute(ctx, &ec2.DeleteRouteInput{
+			DestinationCidrBlock: aws.String("10.243.0.0/19"),
+			RouteTableId:         rt3,
+		})
+		ec2RoutesMock.EXPECT().CreateRoute(ctx, &ec2.CreateRouteInput{
+			DestinationCidrBlock:   routeNode1.DestinationCidrBlock,
+			VpcPeeringConnectionId: aws.String("pcx-13"),
+			RouteTableId:           rt3,
+		})
+
+		result, err := customRoutes.Update(ctx, nodeRoutes, func() {})
+		Expect(err).To(BeNil())
+		Expect(result.SuccessfulRoutes[nodeRoutes[0].PodCIDR]).To(BeTrue())
+		// vpc2 and vpc3 are not peered
+		Expect(result.SuccessfulRoutes[nodeRoutes[1].PodCIDR]).To(BeFalse())
+		Expect(result.Failures).To(HaveLen(1))
+		Expect(result.Failures[nodeRoutes[1].PodCIDR].Reason).To(Equal(updater.ReasonVPCPeeringMissing))
+		Expect(result.Failures[nodeRoutes[1].PodCIDR].Message).To(Equal("no active VPC peering connection between VPC vpc3 of route table rt3 and VPC vpc2 of instance " + nodeRoutes[1].InstanceID))
+		Expect(result.Outcomes).To(ConsistOf(
+			updater.RouteOutcome{Operation: updater.RouteOperationDelete, TableID: *rt1, Destination: *routeNode3.DestinationCidrBlock, InstanceID: *routeNode3.InstanceId},
+			updater.RouteOutcome{Operation: updater.RouteOperationCreate, TableID: *rt1, Destination: *routeNode3.DestinationCidrBlock, InstanceID: nodeRoutes[1].InstanceID, VPCPeeringConnectionID: "pcx-12"},
+			updater.RouteOutcome{Operation: updater.RouteOperationCreate, TableID: *rt2, Destination: *routeNode1.DestinationCidrBlock, InstanceID: nodeRoutes[0].InstanceID, VPCPeeringConnectionID: "pcx-12"},
+			updater.RouteOutcome{Operation: updater.RouteOperationDelete, TableID: *rt3, Destination: "10.243.0.0/19", VPCPeeringConnectionID: "pcx-13"},
+			updater.RouteOutcome{Operation: updater.RouteOperationCreate, TableID: *rt3, Destination: *routeNode1.DestinationCidrBlock, InstanceID: nodeRoutes[0].InstanceID, VPCPeeringConnectionID: "pcx-13"},
+		))
+		Expect(result.Tables).To(Equal([]updater.RouteTableResult{
+			{TableID: *rt1, VPCID: "vpc1", DesiredRoutes: 2, ActualRoutes: 2},
+			{TableID: *rt2, VPCID: "vpc2", DesiredRoutes: 2, ActualRoutes: 2},
+			{TableID: *rt3, VPCID: "vpc3", DesiredRoutes: 1, ActualRoutes: 1},
+		}))
+	})
+	It("should report nodes in unknown VPCs as failures", func() {
+		customRoutes.SetMultiVPC(true)
+		multiVPCTables := []ec2types.RouteTable{
+			{RouteTableId: rt1, VpcId: aws.String("vpc1"), Tags: []ec2types.Tag{clusterTag}, Routes: []ec2types.Route{route1, routeNode1}},
+		}
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: multiVPCTables}, nil)
+		ec2RoutesMock.EXPECT().DescribeInstances(ctx, gomock.Any(), gomock.Any()).Return(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{
+				{InstanceId: aws.String(nodeRoutes[0].InstanceID), VpcId: aws.String("vpc1")},
+			}}},
+		}, nil)
+		ec2RoutesMock.EXPECT().DescribeVpcPeeringConnections(ctx, gomock.Any(), gomock.Any()).Return(&ec2.DescribeVpcPeeringConnectionsOutput{}, nil)
+
+		result, err := customRoutes.Update(ctx, nodeRoutes, func() {})
+		Expect(err).To(BeNil())
+		Expect(result.SuccessfulRoutes[nodeRoutes[0].PodCIDR]).To(BeTrue())
+		Expect(result.SuccessfulRoutes[nodeRoutes[1].PodCIDR]).To(BeFalse())
+		Expect(result.Failures).To(Equal(map[string]*updater.RouteFailure{
+			nodeRoutes[1].PodCIDR: {Reason: updater.ReasonInstanceNotFound, Message: "VPC of instance " + nodeRoutes[1].InstanceID + " not found"},
+		}))
+	})
+	It("should back off failing peering routes", func() {
+		customRoutes.SetMultiVPC(true)
+		customRoutes.SetRouteBackoff(time.Hour, time.Hour, 0, 0)
+		multiVPCTables := []ec2types.RouteTable{
+			{RouteTableId: rt1, VpcId: aws.String("vpc1"), Tags: []ec2types.Tag{clusterTag}, Routes: []ec2types.Route{route1, routeNode1}},
+			{RouteTableId: rt2, VpcId: aws.String("vpc2"), Tags: []ec2types.Tag{clusterTag}, Routes: []ec2types.Route{route1}},
+		}
+		routes := nodeRoutes[:1]
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: multiVPCTables}, nil).Times(2)
+		ec2RoutesMock.EXPECT().DescribeInstances(ctx, gomock.Any(), gomock.Any()).Return(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{
+				{InstanceId: aws.String(routes[0].InstanceID), VpcId: aws.String("vpc1")},
+			}}},
+		}, nil).Times(2)
+		ec2RoutesMock.EXPECT().DescribeVpcPeeringConnections(ctx, gomock.Any(), gomock.Any()).Return(&ec2.DescribeVpcPeeringConnectionsOutput{
+			VpcPeeringConnections: []ec2types.VpcPeeringConnection{
+				{
+					VpcPeeringConnectionId: aws.String("pcx-12"),
+					RequesterVpcInfo:       &ec2types.VpcPeeringConnectionVpcInfo{VpcId: aws.String("vpc1")},
+					AccepterVpcInfo:        &ec2types.VpcPeeringConnectionVpcInfo{VpcId: aws.String("vpc2")},
+				},
+			},
+		}, nil).Times(2)
+		ec2RoutesMock.EXPECT().CreateRoute(ctx, &ec2.CreateRouteInput{
+			DestinationCidrBlock:   routeNode1.DestinationCidrBlock,
+			VpcPeeringConnectionId: aws.String("pcx-12"),
+			RouteTableId:           rt2,
+		}).Return(nil, &smithy.GenericAPIError{Code: "InvalidVpcPeeringConnectionID.NotFound"})
+
+		for i := range 2 {
+			result, err := customRoutes.Update(ctx, routes, func() {})
+			Expect(result.SuccessfulRoutes[routes[0].PodCIDR]).To(BeFalse())
+			Expect(result.Failures[routes[0].PodCIDR]).NotTo(BeNil())
+			Expect(result.Failures[routes[0].PodCIDR].Reason).To(Equal(updater.ReasonRouteOperationFailed))
+			Expect(result.NextRetry).To(BeTemporally(">", time.Now().Add(59*time.Minute)))
+			if i == 0 {
+				var routeErr *updater.RouteError
+				Expect(errors.As(err, &routeErr)).To(BeTrue())
+				Expect(routeErr.Error()).To(ContainSubstring("-> pcx-12 in table rt2"))
+			} else {
+				// the second update is blocked by the backoff
+				Expect(err).To(BeNil())
+			}
+		}
+	})
+	It("should not create peering routes for pod CIDRs in protected CIDRs", func() {
+		customRoutes.SetMultiVPC(true)
+		Expect(customRoutes.SetProtectedCIDRs([]string{"10.243.8.0/22"})).To(Succeed())
+		routes := []updater.NodeRoute{nodeRoutes[0], {InstanceID: *routeNode2.InstanceId, PodCIDR: *routeNode2.DestinationCidrBlock}}
+		multiVPCTables := []ec2types.RouteTable{
+			{RouteTableId: rt1, VpcId: aws.String("vpc1"), Tags: []ec2types.Tag{clusterTag}, Routes: []ec2types.Route{route1, routeNode1}},
+			{RouteTableId: rt3, VpcId: aws.String("vpc3"), Tags: []ec2types.Tag{clusterTag}, Routes: []ec2types.Route{route1}},
+		}
+		ec2RoutesMock.EXPECT().DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: multiVPCTables}, nil)
+		ec2RoutesMock.EXPECT().DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+			Filters: []ec2types.Filter{{Name: aws.String("instance-id"), Values: []string{nodeRoutes[0].InstanceID}}},
+		}, gomock.Any()).Return(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{
+				{InstanceId: aws.String(nodeRoutes[0].InstanceID), VpcId: aws.String("vpc1")},
+			}}},
+		}, nil)
+		ec2RoutesMock.EXPECT().DescribeVpcPeeringConnections(ctx, gomock.Any(), gomock.Any()).Return(&ec2.DescribeVpcPeeringConnectionsOutput{
+			VpcPeeringConnections: []ec2types.VpcPeeringConnection{
+				{
+					VpcPeeringConnectionId: aws.String("pcx-13"),
+					RequesterVpcInfo:       &ec2types.VpcPeeringConnectionVpcInfo{VpcId: aws.String("vpc3")},
+					AccepterVpcInfo:        &ec2types.VpcPeeringConnectionVpcInfo{VpcId: aws.String("vpc1")},
+				},
+			},
+		}, nil)
+		ec2RoutesMock.EXPECT().CreateRoute(ctx, &ec2.CreateRouteInput{
+			DestinationCidrBlock:   routeNode1.DestinationCidrBlock,
+			VpcPeeringConnectionId: aws.String("pcx-13"),
+			RouteTableId:           rt3,
+		})
+
+		result, err := customRoutes.Update(ctx, routes, func() {})
+		Expect(err).To(BeNil())
+		Expect(result.SuccessfulRoutes[*routeNode1.DestinationCidrBlock]).To(BeTrue())
+		Expect(result.SuccessfulRoutes[*routeNode2.DestinationCidrBlock]).To(BeFalse())
+		Expect(result.Failures[*routeNode2.DestinationCidrBlock].Reason).To(Equal(updater.ReasonPodCIDRProtected))
+		Expect(result.Tables).To(Equal([]updater.RouteTableResult{
+			{TableID: *rt1, VPCID: "vpc1", DesiredRoutes: 1, ActualRoutes: 1},
+			{TableID: *rt3, VPCID: "vpc3", DesiredRoutes: 1, ActualRoutes: 1},
+		}))
+	})
 })
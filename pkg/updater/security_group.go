@@ -0,0 +1,167 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package updater
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"go.uber.org/multierr"
+)
+
+const (
+	// SecurityGroupTagKey is the tag key of the cluster security groups which get ingress rules for the pod networks
+	SecurityGroupTagKey = "aws-custom-route-controller.gardener.cloud/pod-network-ingress"
+	// SecurityGroupRuleDescription is the description of the ingress rules managed by the controller
+	SecurityGroupRuleDescription = "pod network (managed by aws-custom-route-controller)"
+
+	allProtocols = "-1"
+)
+
+// SetSecurityGroupRules enables ingress rules for the pod networks in the security groups tagged with the
+// cluster tag and SecurityGroupTagKey.
+func (r *CustomRoutes) SetSecurityGroupRules(enabled bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.securityGroupRules = enabled
+}
+
+// updateSecurityGroupRules ensures an ingress rule for each pod network in the tagged cluster security groups
+// and revokes the managed rules of pod networks which are not managed anymore.
+func (r *CustomRoutes) updateSecurityGroupRules(ctx context.Context, tick func()) error {
+	if !r.securityGroupRules {
+		return nil
+	}
+
+	tick()
+	groupIDs, err := r.findSecurityGroups(ctx)
+	if err != nil {
+		return err
+	}
+	if len(groupIDs) == 0 {
+		return fmt.Errorf("unable to find security group tagged with %s for AWS cluster: %s", SecurityGroupTagKey, r.clusterName)
+	}
+
+	tick()
+	rules, err := r.describeIngressRules(ctx, groupIDs)
+	if err != nil {
+		return err
+	}
+
+	var updateErrors error
+	for _, groupID := range groupIDs {
+		tick()
+		updateErrors = multierr.Append(updateErrors, r.updateSecurityGroup(ctx, groupID, rules[groupID]))
+	}
+	return updateErrors
+}
+
+func (r *CustomRoutes) updateSecurityGroup(ctx context.Context, groupID string, rules []ec2types.SecurityGroupRule) error {
+	desired := map[string]bool{}
+	for _, podNetwork := range r.podNetworks {
+		desired[podNetwork.String()] = true
+	}
+
+	existing := map[string]bool{}
+	var toBeRevoked []string
+	for _, rule := range rules {
+		if aws.ToString(rule.IpProtocol) != allProtocols || rule.CidrIpv4 == nil {
+			continue
+		}
+		if desired[*rule.CidrIpv4] {
+			existing[*rule.CidrIpv4] = true
+			continue
+		}
+		if aws.ToString(rule.Description) == SecurityGroupRuleDescription {
+			toBeRevoked = append(toBeRevoked, aws.ToString(rule.SecurityGroupRuleId))
+		}
+	}
+
+	var toBeAuthorized []ec2types.IpRange
+	for _, podNetwork := range r.podNetworks {
+		if !existing[podNetwork.String()] {
+			toBeAuthorized = append(toBeAuthorized, ec2types.IpRange{
+				CidrIp:      aws.String(podNetwork.String()),
+				Description: aws.String(SecurityGroupRuleDescription),
+			})
+		}
+	}
+
+	var updateErrors error
+	if len(toBeAuthorized) > 0 {
+		_, err := r.ec2.AuthorizeSecurityGroupIngress(ctx, &ec2.AuthorizeSecurityGroupIngressInput{
+			GroupId: aws.String(groupID),
+			IpPermissions: []ec2types.IpPermission{
+				{IpProtocol: aws.String(allProtocols), IpRanges: toBeAuthorized},
+			},
+		})
+		if err != nil {
+			updateErrors = multierr.Append(updateErrors, fmt.Errorf("authorizing pod network ingress in security group %s failed: %w", groupID, err))
+		} else {
+			for _, ipRange := range toBeAuthorized {
+				r.log.Info("security group ingress rule created", "securityGroup", groupID, "source", *ipRange.CidrIp)
+			}
+		}
+	}
+	if len(toBeRevoked) > 0 {
+		_, err := r.ec2.RevokeSecurityGroupIngress(ctx, &ec2.RevokeSecurityGroupIngressInput{
+			GroupId:              aws.String(groupID),
+			SecurityGroupRuleIds: toBeRevoked,
+		})
+		if err != nil {
+			updateErrors = multierr.Append(updateErrors, fmt.Errorf("revoking stale pod network ingress in security group %s failed: %w", groupID, err))
+		} else {
+			r.log.Info("security group ingress rules revoked", "securityGroup", groupID, "rules", toBeRevoked)
+		}
+	}
+	return updateErrors
+}
+
+// findSecurityGroups returns the IDs of the security groups tagged with the cluster tag and SecurityGroupTagKey
+func (r *CustomRoutes) findSecurityGroups(ctx context.Context) ([]string, error) {
+	var groupIDs []string
+	paginator := ec2.NewDescribeSecurityGroupsPaginator(r.ec2, &ec2.DescribeSecurityGroupsInput{
+		Filters: []ec2types.Filter{{Name: aws.String("tag-key"), Values: []string{SecurityGroupTagKey}}},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing security groups failed: %w", err)
+		}
+		for _, group := range page.SecurityGroups {
+			if group.GroupId != nil && hasClusterTag(r.clusterName, group.Tags) {
+				groupIDs = append(groupIDs, *group.GroupId)
+			}
+		}
+	}
+	return groupIDs, nil
+}
+
+// describeIngressRules returns the ingress rules of the given security groups by group ID
+func (r *CustomRoutes) describeIngressRules(ctx context.Context, groupIDs []string) (map[string][]ec2types.SecurityGroupRule, error) {
+	rules := map[string][]ec2types.SecurityGroupRule{}
+	paginator := ec2.NewDescribeSecurityGroupRulesPaginator(r.ec2, &ec2.DescribeSecurityGroupRulesInput{
+		Filters: []ec2types.Filter{{Name: aws.String("group-id"), Values: groupIDs}},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing security group rules failed: %w", err)
+		}
+		for _, rule := range page.SecurityGroupRules {
+			if aws.ToBool(rule.IsEgress) {
+				continue
+			}
+			groupID := aws.ToString(rule.GroupId)
+			rules[groupID] = append(rules[groupID], rule)
+		}
+	}
+	return rules, nil
+}
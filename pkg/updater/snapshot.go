@@ -0,0 +1,100 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package updater
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// errOffline is returned by all modifying operations of the snapshot implementation
+var errOffline = errors.New("operation not supported on route table snapshot")
+
+// snapshotEC2Routes is a read-only EC2Routes implementation backed by a DescribeRouteTables snapshot
+type snapshotEC2Routes struct {
+	routeTables *ec2.DescribeRouteTablesOutput
+}
+
+var _ EC2Routes = (*snapshotEC2Routes)(nil)
+
+// NewSnapshotEC2Routes creates a read-only EC2Routes from a DescribeRouteTables JSON dump,
+// e.g. the output of `aws ec2 describe-route-tables`. It allows calculating route changes without AWS access.
+func NewSnapshotEC2Routes(describeRouteTablesJSON []byte) (EC2Routes, error) {
+	output := &ec2.DescribeRouteTablesOutput{}
+	if err := json.Unmarshal(describeRouteTablesJSON, output); err != nil {
+		return nil, fmt.Errorf("could not parse route tables: %w", err)
+	}
+	return &snapshotEC2Routes{routeTables: output}, nil
+}
+
+func (s *snapshotEC2Routes) DescribeRouteTables(_ context.Context, _ *ec2.DescribeRouteTablesInput, _ ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error) {
+	return s.routeTables, nil
+}
+
+func (s *snapshotEC2Routes) CreateRoute(_ context.Context, _ *ec2.CreateRouteInput, _ ...func(*ec2.Options)) (*ec2.CreateRouteOutput, error) {
+	return nil, errOffline
+}
+
+func (s *snapshotEC2Routes) DeleteRoute(_ context.Context, _ *ec2.DeleteRouteInput, _ ...func(*ec2.Options)) (*ec2.DeleteRouteOutput, error) {
+	return nil, errOffline
+}
+
+func (s *snapshotEC2Routes) DescribeInstances(_ context.Context, _ *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+	return nil, errOffline
+}
+
+func (s *snapshotEC2Routes) DescribeVpcPeeringConnections(_ context.Context, _ *ec2.DescribeVpcPeeringConnectionsInput, _ ...func(*ec2.Options)) (*ec2.DescribeVpcPeeringConnectionsOutput, error) {
+	return nil, errOffline
+}
+
+func (s *snapshotEC2Routes) SearchTransitGatewayRoutes(_ context.Context, _ *ec2.SearchTransitGatewayRoutesInput, _ ...func(*ec2.Options)) (*ec2.SearchTransitGatewayRoutesOutput, error) {
+	return nil, errOffline
+}
+
+func (s *snapshotEC2Routes) CreateTransitGatewayRoute(_ context.Context, _ *ec2.CreateTransitGatewayRouteInput, _ ...func(*ec2.Options)) (*ec2.CreateTransitGatewayRouteOutput, error) {
+	return nil, errOffline
+}
+
+func (s *snapshotEC2Routes) ReplaceTransitGatewayRoute(_ context.Context, _ *ec2.ReplaceTransitGatewayRouteInput, _ ...func(*ec2.Options)) (*ec2.ReplaceTransitGatewayRouteOutput, error) {
+	return nil, errOffline
+}
+
+func (s *snapshotEC2Routes) DeleteTransitGatewayRoute(_ context.Context, _ *ec2.DeleteTransitGatewayRouteInput, _ ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayRouteOutput, error) {
+	return nil, errOffline
+}
+
+func (s *snapshotEC2Routes) DescribeManagedPrefixLists(_ context.Context, _ *ec2.DescribeManagedPrefixListsInput, _ ...func(*ec2.Options)) (*ec2.DescribeManagedPrefixListsOutput, error) {
+	return nil, errOffline
+}
+
+func (s *snapshotEC2Routes) GetManagedPrefixListEntries(_ context.Context, _ *ec2.GetManagedPrefixListEntriesInput, _ ...func(*ec2.Options)) (*ec2.GetManagedPrefixListEntriesOutput, error) {
+	return nil, errOffline
+}
+
+func (s *snapshotEC2Routes) ModifyManagedPrefixList(_ context.Context, _ *ec2.ModifyManagedPrefixListInput, _ ...func(*ec2.Options)) (*ec2.ModifyManagedPrefixListOutput, error) {
+	return nil, errOffline
+}
+
+func (s *snapshotEC2Routes) DescribeSecurityGroups(_ context.Context, _ *ec2.DescribeSecurityGroupsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
+	return nil, errOffline
+}
+
+func (s *snapshotEC2Routes) DescribeSecurityGroupRules(_ context.Context, _ *ec2.DescribeSecurityGroupRulesInput, _ ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupRulesOutput, error) {
+	return nil, errOffline
+}
+
+func (s *snapshotEC2Routes) AuthorizeSecurityGroupIngress(_ context.Context, _ *ec2.AuthorizeSecurityGroupIngressInput, _ ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	return nil, errOffline
+}
+
+func (s *snapshotEC2Routes) RevokeSecurityGroupIngress(_ context.Context, _ *ec2.RevokeSecurityGroupIngressInput, _ ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	return nil, errOffline
+}
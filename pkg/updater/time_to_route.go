@@ -0,0 +1,60 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package updater
+
+import (
+	"sort"
+	"time"
+)
+
+// TimeToRouteAnnotation is the node annotation with the time from the first appearance of the node pod CIDR until
+// its routes were confirmed in all route tables
+const TimeToRouteAnnotation = "aws-custom-route-controller.gardener.cloud/time-to-route"
+
+// TimeToRoute is the time from the first appearance of a node pod CIDR until its routes were confirmed in all
+// route tables
+type TimeToRoute struct {
+	NodeName string
+	PodCIDR  string
+	Duration time.Duration
+}
+
+// Confirmed returns true if the route of the pod CIDR exists in all route tables after the update
+func (r *RouteUpdateResult) Confirmed(podCIDR string) bool {
+	return r.SuccessfulRoutes[podCIDR] && r.Failures[podCIDR] == nil
+}
+
+// StartTimeToRoute starts recording the first appearance of pod CIDRs added from now on.
+// Pod CIDRs already known, e.g. from the initial node list, are not measured.
+func (r *NamedNodeRoutes) StartTimeToRoute() {
+	r.Lock()
+	defer r.Unlock()
+	r.trackTimeToRoute = true
+}
+
+// ConfirmRoutes returns the time to route of all pending pod CIDRs confirmed by the update result, sorted by node
+// name. Each pod CIDR is only returned once.
+func (r *NamedNodeRoutes) ConfirmRoutes(result *RouteUpdateResult, now time.Time) []TimeToRoute {
+	r.Lock()
+	defer r.Unlock()
+	var confirmed []TimeToRoute
+	for name, pending := range r.pending {
+		if r.routes[name].PodCIDR != pending.podCIDR {
+			delete(r.pending, name)
+			continue
+		}
+		if !result.Confirmed(pending.podCIDR) {
+			continue
+		}
+		confirmed = append(confirmed, TimeToRoute{NodeName: name, PodCIDR: pending.podCIDR, Duration: now.Sub(pending.firstSeen)})
+		delete(r.pending, name)
+	}
+	sort.Slice(confirmed, func(i, j int) bool {
+		return confirmed[i].NodeName < confirmed[j].NodeName
+	})
+	return confirmed
+}
@@ -0,0 +1,80 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package updater
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	// tokenRequestExpirationSeconds is the requested lifetime of the workload identity tokens
+	tokenRequestExpirationSeconds = 3600
+	// tokenRequestRefreshBeforeExpiry is the time before expiry after which a new token is requested
+	tokenRequestRefreshBeforeExpiry = 5 * time.Minute
+	// tokenRequestTimeout is the timeout of a single TokenRequest call
+	tokenRequestTimeout = 30 * time.Second
+)
+
+// TokenRequestRetriever retrieves workload identity tokens for a service account with the TokenRequest API.
+// The token is cached until shortly before its expiry.
+type TokenRequestRetriever struct {
+	client    corev1client.ServiceAccountsGetter
+	namespace string
+	name      string
+	audience  string
+
+	lock      sync.Mutex
+	token     []byte
+	expiresAt time.Time
+}
+
+var _ stscreds.IdentityTokenRetriever = (*TokenRequestRetriever)(nil)
+
+// NewTokenRequestRetriever creates a TokenRequestRetriever for the given service account and audience
+func NewTokenRequestRetriever(client corev1client.ServiceAccountsGetter, namespace, name, audience string) *TokenRequestRetriever {
+	return &TokenRequestRetriever{
+		client:    client,
+		namespace: namespace,
+		name:      name,
+		audience:  audience,
+	}
+}
+
+// GetIdentityToken returns the cached token or requests a new one if it expires soon
+func (t *TokenRequestRetriever) GetIdentityToken() ([]byte, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.token != nil && time.Now().Add(tokenRequestRefreshBeforeExpiry).Before(t.expiresAt) {
+		return t.token, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tokenRequestTimeout)
+	defer cancel()
+	expirationSeconds := int64(tokenRequestExpirationSeconds)
+	tokenRequest := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         []string{t.audience},
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}
+	response, err := t.client.ServiceAccounts(t.namespace).CreateToken(ctx, t.name, tokenRequest, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("requesting token for service account %s/%s failed: %w", t.namespace, t.name, err)
+	}
+	t.token = []byte(response.Status.Token)
+	t.expiresAt = response.Status.ExpirationTimestamp.Time
+	return t.token, nil
+}
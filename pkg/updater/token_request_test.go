@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package updater_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/gardener/aws-custom-route-controller/pkg/updater"
+)
+
+var _ = Describe("TokenRequestRetriever", func() {
+	var (
+		clientset *fake.Clientset
+		requests  []*authenticationv1.TokenRequest
+		lifetime  time.Duration
+	)
+
+	BeforeEach(func() {
+		clientset = fake.NewClientset()
+		requests = nil
+		clientset.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			createAction := action.(k8stesting.CreateAction)
+			Expect(createAction.GetSubresource()).To(Equal("token"))
+			Expect(createAction.GetNamespace()).To(Equal("kube-system"))
+			tokenRequest := createAction.GetObject().(*authenticationv1.TokenRequest)
+			requests = append(requests, tokenRequest)
+			tokenRequest = tokenRequest.DeepCopy()
+			tokenRequest.Status = authenticationv1.TokenRequestStatus{
+				Token:               "token",
+				ExpirationTimestamp: metav1.NewTime(time.Now().Add(lifetime)),
+			}
+			return true, tokenRequest, nil
+		})
+	})
+
+	It("should request a token and cache it", func() {
+		lifetime = time.Hour
+		retriever := updater.NewTokenRequestRetriever(clientset.CoreV1(), "kube-system", "aws-custom-route-controller", "sts.amazonaws.com")
+
+		for range 2 {
+			token, err := retriever.GetIdentityToken()
+			Expect(err).To(BeNil())
+			Expect(string(token)).To(Equal("token"))
+		}
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Spec.Audiences).To(Equal([]string{"sts.amazonaws.com"}))
+	})
+
+	It("should request a new token shortly before expiry", func() {
+		lifetime = time.Minute
+		retriever := updater.NewTokenRequestRetriever(clientset.CoreV1(), "kube-system", "aws-custom-route-controller", "sts.amazonaws.com")
+
+		for range 2 {
+			_, err := retriever.GetIdentityToken()
+			Expect(err).To(BeNil())
+		}
+		Expect(requests).To(HaveLen(2))
+	})
+})
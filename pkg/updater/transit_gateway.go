@@ -0,0 +1,257 @@
+/*
+ * SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package updater
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"go.uber.org/multierr"
+
+	"github.com/gardener/aws-custom-route-controller/pkg/util"
+)
+
+// maxTransitGatewayRoutes is the maximum number of routes returned by a single transit gateway route search
+const maxTransitGatewayRoutes = 1000
+
+// SetTransitGateway enables static routes for the pod networks in the given transit gateway route tables
+// pointing at the transit gateway attachment of the cluster VPC.
+func (r *CustomRoutes) SetTransitGateway(routeTableIDs []string, attachmentID string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.transitGatewayRouteTableIDs = routeTableIDs
+	r.transitGatewayAttachmentID = attachmentID
+}
+
+// updateTransitGatewayRoutes ensures a static route for each pod network in all transit gateway route tables and
+// deletes the other managed static routes to the attachment, see isManagedTransitGatewayRoute
+func (r *CustomRoutes) updateTransitGatewayRoutes(ctx context.Context, result *RouteUpdateResult, tick func()) error {
+	var updateErrors error
+	for _, tableID := range r.transitGatewayRouteTableIDs {
+		tick()
+		updateErrors = multierr.Append(updateErrors, r.updateTransitGatewayRouteTable(ctx, tableID, result, tick))
+	}
+	return updateErrors
+}
+
+func (r *CustomRoutes) updateTransitGatewayRouteTable(ctx context.Context, tableID string, result *RouteUpdateResult, tick func()) error {
+	tableResult := RouteTableResult{TableID: tableID}
+	defer func() {
+		result.countActualRoutes(&tableResult)
+		result.TransitGatewayTables = append(result.TransitGatewayTables, tableResult)
+	}()
+
+	existing, err := r.searchTransitGatewayRoutes(ctx, tableID, r.podNetworkFilter())
+	if err != nil {
+		return err
+	}
+
+	var updateErrors error
+	desired := map[string]bool{}
+	for _, route := range r.transitGatewayRoutes() {
+		desired[route.destinationCidrBlock] = true
+		tableResult.DesiredRoutes++
+		current, ok := existing[route.destinationCidrBlock]
+		switch {
+		case !ok:
+			tick()
+			_, err := r.ec2.CreateTransitGatewayRoute(ctx, &ec2.CreateTransitGatewayRouteInput{
+				DestinationCidrBlock:       aws.String(route.destinationCidrBlock),
+				TransitGatewayRouteTableId: aws.String(tableID),
+				TransitGatewayAttachmentId: aws.String(route.transitGatewayAttachmentId),
+			})
+			result.addTransitGatewayOutcome(RouteOperationCreate, tableID, route, err)
+			if err != nil {
+				updateErrors = multierr.Append(updateErrors, newRouteError(RouteOperationCreate, tableID, route, "", err))
+				continue
+			}
+			r.log.Info("transit gateway route created", "table", tableID, "destination", route.destinationCidrBlock, "attachmentId", route.transitGatewayAttachmentId)
+		case hasTransitGatewayAttachment(current, route.transitGatewayAttachmentId):
+			tableResult.ActualRoutes++
+		case current.State == ec2types.TransitGatewayRouteStateBlackhole:
+			tick()
+			_, err := r.ec2.ReplaceTransitGatewayRoute(ctx, &ec2.ReplaceTransitGatewayRouteInput{
+				DestinationCidrBlock:       aws.String(route.destinationCidrBlock),
+				TransitGatewayRouteTableId: aws.String(tableID),
+				TransitGatewayAttachmentId: aws.String(route.transitGatewayAttachmentId),
+			})
+			result.addTransitGatewayOutcome(RouteOperationReplace, tableID, route, err)
+			if err != nil {
+				updateErrors = multierr.Append(updateErrors, newRouteError(RouteOperationReplace, tableID, route, "", err))
+				continue
+			}
+			r.log.Info("blackhole transit gateway route replaced", "table", tableID, "destination", route.destinationCidrBlock, "attachmentId", route.transitGatewayAttachmentId)
+		default:
+			// never take over a route of another attachment
+			updateErrors = multierr.Append(updateErrors, fmt.Errorf("transit gateway route %s in table %s points at another attachment", route.destinationCidrBlock, tableID))
+		}
+	}
+
+	owned, err := r.searchTransitGatewayRoutes(ctx, tableID, r.attachmentFilter())
+	if err != nil {
+		return multierr.Append(updateErrors, err)
+	}
+	var stale []string
+	for destination := range owned {
+		if !desired[destination] && r.isManagedTransitGatewayRoute(destination) {
+			stale = append(stale, destination)
+		}
+	}
+	sort.Strings(stale)
+	// stale routes are counted as actual routes until they are deleted
+	tableResult.ActualRoutes += len(stale)
+	for _, destination := range stale {
+		tick()
+		updateErrors = multierr.Append(updateErrors, r.deleteTransitGatewayRoute(ctx, tableID, destination, result))
+	}
+	return updateErrors
+}
+
+// deleteTransitGatewayRoutes deletes the managed static routes pointing at the attachment of the cluster VPC
+func (r *CustomRoutes) deleteTransitGatewayRoutes(ctx context.Context, result *RouteUpdateResult) error {
+	var cleanupErrors error
+	for _, tableID := range r.transitGatewayRouteTableIDs {
+		owned, err := r.searchTransitGatewayRoutes(ctx, tableID, r.attachmentFilter())
+		if err != nil {
+			cleanupErrors = multierr.Append(cleanupErrors, err)
+			continue
+		}
+		tableResult := RouteTableResult{TableID: tableID}
+		var destinations []string
+		for destination := range owned {
+			if r.isManagedTransitGatewayRoute(destination) {
+				destinations = append(destinations, destination)
+			}
+		}
+		sort.Strings(destinations)
+		for _, destination := range destinations {
+			if err := r.deleteTransitGatewayRoute(ctx, tableID, destination, result); err != nil {
+				tableResult.ActualRoutes++
+				cleanupErrors = multierr.Append(cleanupErrors, err)
+			}
+		}
+		result.TransitGatewayTables = append(result.TransitGatewayTables, tableResult)
+	}
+	return cleanupErrors
+}
+
+// deleteTransitGatewayRoute deletes the static route to the attachment of the cluster VPC and records the outcome
+func (r *CustomRoutes) deleteTransitGatewayRoute(ctx context.Context, tableID, destination string, result *RouteUpdateResult) error {
+	route := internalNodeRoute{
+		destinationCidrBlock:       destination,
+		transitGatewayAttachmentId: r.transitGatewayAttachmentID,
+	}
+	_, err := r.ec2.DeleteTransitGatewayRoute(ctx, &ec2.DeleteTransitGatewayRouteInput{
+		DestinationCidrBlock:       aws.String(destination),
+		TransitGatewayRouteTableId: aws.String(tableID),
+	})
+	result.addTransitGatewayOutcome(RouteOperationDelete, tableID, route, err)
+	if err != nil {
+		return newRouteError(RouteOperationDelete, tableID, route, "", err)
+	}
+	r.log.Info("transit gateway route deleted", "table", tableID, "destination", destination)
+	return nil
+}
+
+// transitGatewayRoutes returns the desired transit gateway routes for all pod networks.
+// Protected CIDRs are not excluded, their more specific routes take precedence anyway.
+func (r *CustomRoutes) transitGatewayRoutes() []internalNodeRoute {
+	var routes []internalNodeRoute
+	for _, podNetwork := range r.podNetworks {
+		routes = append(routes, internalNodeRoute{
+			destinationCidrBlock:       podNetwork.String(),
+			transitGatewayAttachmentId: r.transitGatewayAttachmentID,
+		})
+	}
+	return routes
+}
+
+// isManagedTransitGatewayRoute returns true for the destination of a static route to the attachment of the cluster VPC
+// which is managed by the controller: the route of a pod network or of a CIDR inside a pod network, unless it overlaps
+// a protected CIDR. Other routes to the attachment, e.g. for the VPC CIDR, are never changed.
+func (r *CustomRoutes) isManagedTransitGatewayRoute(destination string) bool {
+	_, ipnet, err := net.ParseCIDR(destination)
+	if err != nil {
+		return false
+	}
+	inPodNetwork := false
+	for i := range r.podNetworks {
+		if r.podNetworks[i].String() == ipnet.String() {
+			// routes for a whole pod network are managed even if they contain protected CIDRs
+			return true
+		}
+		if util.NetworkContains(&r.podNetworks[i], ipnet) {
+			inPodNetwork = true
+		}
+	}
+	return inPodNetwork && r.findProtectedNetwork(destination) == nil
+}
+
+// searchTransitGatewayRoutes returns the static routes matching the filter in the transit gateway route table by
+// destination. The search has no pagination, so a truncated result is an error to never act on a partial view.
+func (r *CustomRoutes) searchTransitGatewayRoutes(ctx context.Context, tableID string, filter ec2types.Filter) (map[string]ec2types.TransitGatewayRoute, error) {
+	response, err := r.ec2.SearchTransitGatewayRoutes(ctx, &ec2.SearchTransitGatewayRoutesInput{
+		TransitGatewayRouteTableId: aws.String(tableID),
+		Filters: []ec2types.Filter{
+			{Name: aws.String("type"), Values: []string{string(ec2types.TransitGatewayRouteTypeStatic)}},
+			filter,
+		},
+		MaxResults: aws.Int32(maxTransitGatewayRoutes),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("searching routes of transit gateway route table %s failed: %w", tableID, err)
+	}
+	if aws.ToBool(response.AdditionalRoutesAvailable) {
+		return nil, fmt.Errorf("searching routes of transit gateway route table %s returned more than %d routes", tableID, maxTransitGatewayRoutes)
+	}
+
+	routes := map[string]ec2types.TransitGatewayRoute{}
+	for _, route := range response.Routes {
+		if route.DestinationCidrBlock != nil {
+			routes[*route.DestinationCidrBlock] = route
+		}
+	}
+	return routes, nil
+}
+
+// podNetworkFilter matches the routes of the pod networks
+func (r *CustomRoutes) podNetworkFilter() ec2types.Filter {
+	var destinations []string
+	for _, podNetwork := range r.podNetworks {
+		destinations = append(destinations, podNetwork.String())
+	}
+	return ec2types.Filter{Name: aws.String("route-search.exact-match"), Values: destinations}
+}
+
+// attachmentFilter matches the routes to the attachment of the cluster VPC, including routes not managed by the controller
+func (r *CustomRoutes) attachmentFilter() ec2types.Filter {
+	return ec2types.Filter{Name: aws.String("attachment.transit-gateway-attachment-id"), Values: []string{r.transitGatewayAttachmentID}}
+}
+
+func hasTransitGatewayAttachment(route ec2types.TransitGatewayRoute, attachmentID string) bool {
+	for _, attachment := range route.TransitGatewayAttachments {
+		if aws.ToString(attachment.TransitGatewayAttachmentId) == attachmentID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RouteUpdateResult) addTransitGatewayOutcome(op RouteOperation, tableID string, route internalNodeRoute, err error) {
+	r.Outcomes = append(r.Outcomes, RouteOutcome{
+		Operation:                  op,
+		TableID:                    tableID,
+		Destination:                route.destinationCidrBlock,
+		TransitGatewayAttachmentID: route.transitGatewayAttachmentId,
+		Err:                        err,
+	})
+}
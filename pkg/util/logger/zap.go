@@ -21,6 +21,20 @@ func setCommonEncoderConfigOptions(encoderConfig *zapcore.EncoderConfig) {
 	encoderConfig.EncodeDuration = zapcore.StringDurationEncoder
 }
 
+// ParseLevel maps our log levels to zap levels.
+func ParseLevel(level string) (zapcore.Level, error) {
+	switch level {
+	case DebugLevel:
+		return zap.DebugLevel, nil
+	case ErrorLevel:
+		return zap.ErrorLevel, nil
+	case "", InfoLevel:
+		return zap.InfoLevel, nil
+	default:
+		return zap.InfoLevel, fmt.Errorf("invalid log level %q", level)
+	}
+}
+
 // MustNewZapLogger is like NewZapLogger but panics on invalid input.
 func MustNewZapLogger(level string, format string, additionalOpts ...logzap.Opts) logr.Logger {
 	logger, err := NewZapLogger(level, format, additionalOpts...)
@@ -32,17 +46,9 @@ func MustNewZapLogger(level string, format string, additionalOpts ...logzap.Opts
 func NewZapLogger(level string, format string, additionalOpts ...logzap.Opts) (logr.Logger, error) {
 	var opts []logzap.Opts
 
-	// map our log levels to zap levels
-	var zapLevel zapcore.LevelEnabler
-	switch level {
-	case DebugLevel:
-		zapLevel = zap.DebugLevel
-	case ErrorLevel:
-		zapLevel = zap.ErrorLevel
-	case "", InfoLevel:
-		zapLevel = zap.InfoLevel
-	default:
-		return logr.Logger{}, fmt.Errorf("invalid log level %q", level)
+	zapLevel, err := ParseLevel(level)
+	if err != nil {
+		return logr.Logger{}, err
 	}
 	opts = append(opts, logzap.Level(zapLevel))
 
@@ -12,6 +12,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -91,3 +92,21 @@ func SetNodeCondition(ctx context.Context, c client.Client, nodeName types.NodeN
 
 	return nil
 }
+
+// SetNodeAnnotation sets the annotation of the node with a merge patch
+func SetNodeAnnotation(ctx context.Context, c client.Client, nodeName types.NodeName, key, value string) error {
+	patchBytes, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{key: value},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch: %w", err)
+	}
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: string(nodeName)}}
+	if err := c.Patch(ctx, node, client.RawPatch(types.MergePatchType, patchBytes)); err != nil {
+		return fmt.Errorf("failed to patch node annotation: %w", err)
+	}
+	return nil
+}
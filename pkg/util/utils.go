@@ -23,3 +23,31 @@ func GetIPv4CIDR(cidrs []string) (string, error) {
 	}
 	return "", nil
 }
+
+// GetIPv4CIDRs returns all IPv4 CIDRs
+func GetIPv4CIDRs(cidrs []string) ([]string, error) {
+	var result []string
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			err := fmt.Errorf("unable to parse cidr: %s", cidr)
+			return nil, err
+		}
+		if ipNet.IP.To4() != nil {
+			result = append(result, cidr)
+		}
+	}
+	return result, nil
+}
+
+// NetworksOverlap checks if two networks share at least one address
+func NetworksOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// NetworkContains checks if the inner network is completely contained in the outer network
+func NetworkContains(outer, inner *net.IPNet) bool {
+	outerOnes, outerBits := outer.Mask.Size()
+	innerOnes, innerBits := inner.Mask.Size()
+	return outerBits == innerBits && outerOnes <= innerOnes && outer.Contains(inner.IP)
+}
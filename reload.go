@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/utils/ptr"
+
+	configv1alpha1 "github.com/gardener/aws-custom-route-controller/pkg/apis/config/v1alpha1"
+	"github.com/gardener/aws-custom-route-controller/pkg/controller"
+	"github.com/gardener/aws-custom-route-controller/pkg/updater"
+	"github.com/gardener/aws-custom-route-controller/pkg/util"
+	"github.com/gardener/aws-custom-route-controller/pkg/util/logger"
+)
+
+// configReloader watches the configuration file and applies changes to the running components
+type configReloader struct {
+	log          logr.Logger
+	current      *configv1alpha1.ControllerConfiguration
+	reconciler   *controller.NodeReconciler
+	customRoutes *updater.CustomRoutes
+}
+
+// start watches the directory of the configuration file, as a mounted ConfigMap is updated by replacing a symlink
+func (c *configReloader) start(ctx context.Context, path string) error {
+	return watchDirectory(ctx, c.log, filepath.Dir(path), func() {
+		if err := c.reload(); err != nil {
+			c.log.Error(err, "configuration reload rejected, keeping running configuration", "config", path)
+		}
+	})
+}
+
+// watchDirectory calls onChange for every change in the directory until the context is cancelled
+func watchDirectory(ctx context.Context, log logr.Logger, dir string, onChange func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(dir); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer func() { _ = watcher.Close() }()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-watcher.Events:
+				if event.Has(fsnotify.Chmod) {
+					continue
+				}
+				onChange()
+			case err := <-watcher.Errors:
+				log.Error(err, "watching directory failed", "directory", dir)
+			}
+		}
+	}()
+	return nil
+}
+
+// reloadCredentials replaces the AWS clients of the updater with clients using the reloaded credentials
+func reloadCredentials(cfg *configv1alpha1.ControllerConfiguration, log logr.Logger, customRoutes *updater.CustomRoutes) {
+	ec2Routes, callerIdentity, err := newAWSClients(cfg)
+	if err != nil {
+		log.Error(err, "credentials reload failed, keeping running credentials")
+		return
+	}
+	customRoutes.SetAWSClients(ec2Routes, callerIdentity)
+	log.Info("credentials reloaded", "directory", cfg.Credentials.Directory)
+}
+
+// reload loads the configuration and applies the settings which can be changed at runtime.
+// The configuration is rejected as a whole if it is invalid or changes any other setting.
+func (c *configReloader) reload() error {
+	cfg, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+	if apiequality.Semantic.DeepEqual(cfg, c.current) {
+		return nil
+	}
+
+	allErrs := configv1alpha1.ValidateControllerConfiguration(cfg)
+	allErrs = append(allErrs, configv1alpha1.ValidateAWSConfiguration(cfg)...)
+	allErrs = append(allErrs, configv1alpha1.ValidateTargetConfiguration(cfg)...)
+	allErrs = append(allErrs, configv1alpha1.ValidateControllerConfigurationUpdate(cfg, c.current)...)
+	if err := allErrs.ToAggregate(); err != nil {
+		return err
+	}
+
+	// parse everything before applying anything, so that a failing reload leaves the running settings unchanged
+	level, err := logger.ParseLevel(cfg.Log.Level)
+	if err != nil {
+		return err
+	}
+	podCIDRs, err := util.GetIPv4CIDRs(cfg.PodNetworkCIDRs)
+	if err != nil {
+		return fmt.Errorf("could not parse IPv4 address from pod network CIDRs: %w", err)
+	}
+
+	var changed []string
+	if !slices.Equal(cfg.PodNetworkCIDRs, c.current.PodNetworkCIDRs) || !slices.Equal(cfg.ProtectedCIDRs, c.current.ProtectedCIDRs) {
+		if err := c.applyNetworks(podCIDRs, cfg.ProtectedCIDRs); err != nil {
+			return err
+		}
+		changed = append(changed, "podNetworkCIDRs", "protectedCIDRs")
+	}
+	if !apiequality.Semantic.DeepEqual(cfg.Sync, c.current.Sync) {
+		c.reconciler.SetUpdaterTimings(cfg.Sync.TickPeriod.Duration, cfg.Sync.SyncPeriod.Duration, cfg.Sync.MaxDelayOnFailure.Duration)
+		c.reconciler.SetReadySyncPeriods(cfg.Sync.ReadySyncPeriods)
+		setRouteBackoff(c.customRoutes, cfg.Sync)
+		changed = append(changed, "sync")
+	}
+	if !apiequality.Semantic.DeepEqual(cfg.RouteLimit, c.current.RouteLimit) {
+		c.customRoutes.SetRouteQuota(updater.StaticRouteQuota(cfg.RouteLimit.Limit))
+		c.reconciler.SetRouteLimitWarningHeadroom(ptr.Deref(cfg.RouteLimit.WarningHeadroom, 0))
+		changed = append(changed, "routeLimit")
+	}
+	if cfg.TimeToRouteAnnotation != c.current.TimeToRouteAnnotation {
+		c.reconciler.SetTimeToRouteAnnotation(cfg.TimeToRouteAnnotation)
+		changed = append(changed, "timeToRouteAnnotation")
+	}
+	if cfg.Log.Level != c.current.Log.Level {
+		atomicLogLevel.SetLevel(level)
+		changed = append(changed, "log.level")
+	}
+
+	c.current = cfg
+	c.log.Info("configuration reloaded", "changed", changed,
+		"syncPeriod", cfg.Sync.SyncPeriod.Duration, "tickPeriod", cfg.Sync.TickPeriod.Duration, "maxDelayOnFailure", cfg.Sync.MaxDelayOnFailure.Duration,
+		"podNetworkCIDRs", cfg.PodNetworkCIDRs, "protectedCIDRs", cfg.ProtectedCIDRs, "routeLimit", cfg.RouteLimit.Limit, "logLevel", cfg.Log.Level)
+	return nil
+}
+
+// applyNetworks sets the pod network and protected CIDRs and triggers a route update.
+// The CIDRs must be validated before, as a failure would leave them partially applied.
+func (c *configReloader) applyNetworks(podCIDRs, protectedCIDRs []string) error {
+	if err := c.customRoutes.SetPodNetworks(podCIDRs); err != nil {
+		return err
+	}
+	if err := c.customRoutes.SetProtectedCIDRs(protectedCIDRs); err != nil {
+		return err
+	}
+	return c.reconciler.SetPodNetworks(podCIDRs)
+}